@@ -60,6 +60,35 @@ var schemaYAML = typed.YAMLObject(`types:
     - name: namespace
       type:
         scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.APIKey
+  map:
+    fields:
+    - name: endpoints
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: associative
+    - name: name
+      type:
+        scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.Auth
+  map:
+    fields:
+    - name: keys
+      type:
+        list:
+          elementType:
+            namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.APIKey
+          elementRelationship: associative
+          keys:
+          - name
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.AuthStatus
+  map:
+    fields:
+    - name: secretName
+      type:
+        scalar: string
 - name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ConfigMapKeySelector
   map:
     fields:
@@ -69,48 +98,596 @@ var schemaYAML = typed.YAMLObject(`types:
     - name: name
       type:
         scalar: string
-    - name: namespace
+    - name: namespace
+      type:
+        scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.Expose
+  map:
+    fields:
+    - name: className
+      type:
+        scalar: string
+    - name: gatewayRef
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.GatewayReference
+    - name: host
+      type:
+        scalar: string
+    - name: patches
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.Patches
+    - name: path
+      type:
+        scalar: string
+    - name: tlsSecretRef
+      type:
+        namedType: io.k8s.api.core.v1.LocalObjectReference
+    - name: type
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ExposeType
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ExposeStatus
+  map:
+    fields:
+    - name: addresses
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: atomic
+    - name: kind
+      type:
+        scalar: string
+    - name: name
+      type:
+        scalar: string
+    - name: url
+      type:
+        scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ExposeType
+  scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.GatewayReference
+  map:
+    fields:
+    - name: name
+      type:
+        scalar: string
+    - name: namespace
+      type:
+        scalar: string
+    - name: sectionName
+      type:
+        scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.HostedModel
+  map:
+    fields:
+    - name: models
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: associative
+    - name: name
+      type:
+        scalar: string
+    - name: ready
+      type:
+        scalar: boolean
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ImageData
+  map:
+    fields:
+    - name: data
+      type:
+        scalar: string
+    - name: format
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ImageFormat
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ImageFormat
+  scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ImageSource
+  map:
+    fields:
+    - name: configMapKeyRef
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ConfigMapKeySelector
+    - name: inline
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ImageData
+    - name: secretKeyRef
+      type:
+        namedType: com.github.crossplane.crossplane.apis.v2.core.v2.SecretKeySelector
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.JSONPatchOperation
+  map:
+    fields:
+    - name: from
+      type:
+        scalar: string
+    - name: op
+      type:
+        scalar: string
+    - name: path
+      type:
+        scalar: string
+    - name: value
+      type:
+        namedType: __untyped_atomic_
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.Model
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+    - name: kind
+      type:
+        scalar: string
+    - name: metadata
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta
+    - name: spec
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelSpec
+    - name: status
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelStatus
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelAccessGrant
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+    - name: kind
+      type:
+        scalar: string
+    - name: metadata
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta
+    - name: spec
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelAccessGrantSpec
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelAccessGrantFrom
+  map:
+    fields:
+    - name: namespace
+      type:
+        scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelAccessGrantSpec
+  map:
+    fields:
+    - name: from
+      type:
+        list:
+          elementType:
+            namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelAccessGrantFrom
+          elementRelationship: atomic
+    - name: to
+      type:
+        list:
+          elementType:
+            namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelAccessGrantTo
+          elementRelationship: atomic
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelAccessGrantTo
+  map:
+    fields:
+    - name: name
+      type:
+        scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelCreate
+  map:
+    fields:
+    - name: adapters
+      type:
+        list:
+          elementType:
+            namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelFile
+          elementRelationship: associative
+          keys:
+          - name
+    - name: files
+      type:
+        list:
+          elementType:
+            namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelFile
+          elementRelationship: associative
+          keys:
+          - name
+    - name: from
+      type:
+        scalar: string
+    - name: license
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: atomic
+    - name: messages
+      type:
+        list:
+          elementType:
+            namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelMessage
+          elementRelationship: atomic
+    - name: modelfileRef
+      type:
+        namedType: io.k8s.api.core.v1.ConfigMapKeySelector
+    - name: parameters
+      type:
+        list:
+          elementType:
+            namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelParameter
+          elementRelationship: atomic
+    - name: system
+      type:
+        scalar: string
+    - name: template
+      type:
+        scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelEntry
+  map:
+    fields:
+    - name: create
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelCreate
+    - name: digest
+      type:
+        scalar: string
+    - name: name
+      type:
+        scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelEntryStatus
+  map:
+    fields:
+    - name: createHash
+      type:
+        scalar: string
+    - name: details
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.OllamaModelDetails
+    - name: digest
+      type:
+        scalar: string
+    - name: failedGeneration
+      type:
+        scalar: numeric
+    - name: failureReason
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.PullFailureReason
+    - name: lastPullError
+      type:
+        scalar: string
+    - name: load
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelLoadStatus
+    - name: message
+      type:
+        scalar: string
+    - name: modifiedAt
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: name
+      type:
+        scalar: string
+    - name: nextPullAttemptTime
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: pullAttempts
+      type:
+        scalar: numeric
+    - name: pullState
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.PullState
+    - name: ready
+      type:
+        scalar: boolean
+    - name: registry
+      type:
+        scalar: string
+    - name: seededFrom
+      type:
+        scalar: string
+    - name: size
+      type:
+        scalar: numeric
+    - name: update
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelUpdateStatus
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelFile
+  map:
+    fields:
+    - name: configMapKeyRef
+      type:
+        namedType: io.k8s.api.core.v1.ConfigMapKeySelector
+    - name: digest
+      type:
+        scalar: string
+    - name: name
+      type:
+        scalar: string
+    - name: persistentVolumeClaim
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.PersistentVolumeClaimFile
+    - name: secretKeyRef
+      type:
+        namedType: io.k8s.api.core.v1.SecretKeySelector
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelLoadStatus
+  map:
+    fields:
+    - name: expiresAt
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: loadedReplicas
+      type:
+        scalar: numeric
+    - name: sizeRAM
+      type:
+        scalar: numeric
+    - name: sizeVRAM
+      type:
+        scalar: numeric
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelMessage
+  map:
+    fields:
+    - name: content
+      type:
+        scalar: string
+    - name: role
+      type:
+        scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelParameter
+  map:
+    fields:
+    - name: name
+      type:
+        scalar: string
+    - name: value
+      type:
+        scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelRef
+  map:
+    fields:
+    - name: model
+      type:
+        scalar: string
+    - name: name
+      type:
+        scalar: string
+    - name: namespace
+      type:
+        scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelSource
+  map:
+    fields:
+    - name: imageVolume
+      type:
+        namedType: io.k8s.api.core.v1.ImageVolumeSource
+    - name: snapshot
+      type:
+        namedType: io.k8s.api.core.v1.LocalObjectReference
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelSpec
+  map:
+    fields:
+    - name: auth
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.Auth
+    - name: cleanupOnDelete
+      type:
+        scalar: boolean
+    - name: create
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelCreate
+    - name: digest
+      type:
+        scalar: string
+    - name: expose
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.Expose
+    - name: idle
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Duration
+    - name: model
+      type:
+        scalar: string
+    - name: models
+      type:
+        list:
+          elementType:
+            namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelEntry
+          elementRelationship: associative
+          keys:
+          - name
+    - name: networkPolicy
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.NetworkPolicy
+    - name: ollamaImage
+      type:
+        scalar: string
+    - name: pullMode
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.PullMode
+    - name: registry
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.Registry
+    - name: replicas
+      type:
+        scalar: numeric
+    - name: server
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.Server
+    - name: serverRef
+      type:
+        namedType: io.k8s.api.core.v1.LocalObjectReference
+    - name: servicePatches
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.Patches
+    - name: source
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelSource
+    - name: statefulSetPatches
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.Patches
+    - name: storage
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.Storage
+    - name: suspend
+      type:
+        scalar: boolean
+    - name: updatePolicy
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.UpdatePolicy
+    - name: warmUp
+      type:
+        scalar: boolean
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelStatus
+  map:
+    fields:
+    - name: auth
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.AuthStatus
+    - name: conditions
+      type:
+        list:
+          elementType:
+            namedType: com.github.crossplane.crossplane.apis.v2.core.v2.Condition
+          elementRelationship: associative
+          keys:
+          - type
+    - name: expose
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ExposeStatus
+    - name: lastUsedTime
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: modelDetails
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.OllamaModelDetails
+    - name: models
+      type:
+        list:
+          elementType:
+            namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelEntryStatus
+          elementRelationship: associative
+          keys:
+          - name
+    - name: observedGeneration
+      type:
+        scalar: numeric
+    - name: ollamaImage
+      type:
+        scalar: string
+    - name: pendingChanges
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: atomic
+    - name: pull
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.PullProgress
+    - name: pulledModels
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: associative
+    - name: readyReplicas
+      type:
+        scalar: numeric
+    - name: replicas
+      type:
+        list:
+          elementType:
+            namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ReplicaStatus
+          elementRelationship: associative
+          keys:
+          - pod
+    - name: retentionPolicy
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.PVCRetentionPolicy
+    - name: scaledToZero
+      type:
+        scalar: boolean
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelUpdateStatus
+  map:
+    fields:
+    - name: lastCheckTime
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: lastError
+      type:
+        scalar: string
+    - name: lastUpdateTime
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: previousDigest
+      type:
+        scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.NetworkPolicy
+  map:
+    fields:
+    - name: from
+      type:
+        list:
+          elementType:
+            namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.NetworkPolicyPeer
+          elementRelationship: atomic
+    - name: patches
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.Patches
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.NetworkPolicyPeer
+  map:
+    fields:
+    - name: namespaceSelector
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelector
+    - name: podSelector
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelector
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.OllamaModelDetails
+  map:
+    fields:
+    - name: architecture
+      type:
+        scalar: string
+    - name: capabilities
       type:
-        scalar: string
-- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ImageData
-  map:
-    fields:
-    - name: data
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: associative
+    - name: contextLength
+      type:
+        scalar: numeric
+    - name: families
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: atomic
+    - name: family
       type:
         scalar: string
     - name: format
       type:
-        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ImageFormat
-- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ImageFormat
-  scalar: string
-- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ImageSource
-  map:
-    fields:
-    - name: configMapKeyRef
+        scalar: string
+    - name: license
       type:
-        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ConfigMapKeySelector
-    - name: inline
+        scalar: string
+    - name: parameterSize
       type:
-        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ImageData
-    - name: secretKeyRef
+        scalar: string
+    - name: parameters
       type:
-        namedType: com.github.crossplane.crossplane.apis.v2.core.v2.SecretKeySelector
-- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.JSONPatchOperation
-  map:
-    fields:
-    - name: from
+        scalar: string
+    - name: parentModel
       type:
         scalar: string
-    - name: op
+    - name: quantizationLevel
       type:
         scalar: string
-    - name: path
+    - name: system
       type:
         scalar: string
-    - name: value
+    - name: template
       type:
-        namedType: __untyped_atomic_
-- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.Model
+        scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.OllamaServer
   map:
     fields:
     - name: apiVersion
@@ -124,35 +701,38 @@ var schemaYAML = typed.YAMLObject(`types:
         namedType: io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta
     - name: spec
       type:
-        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelSpec
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.OllamaServerSpec
     - name: status
       type:
-        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelStatus
-- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelRef
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.OllamaServerStatus
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.OllamaServerSpec
   map:
     fields:
-    - name: name
+    - name: networkPolicy
       type:
-        scalar: string
-    - name: namespace
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.NetworkPolicy
+    - name: ollamaImage
       type:
         scalar: string
-- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelSpec
-  map:
-    fields:
-    - name: model
+    - name: registryCredentialsSecretRef
       type:
-        scalar: string
-    - name: ollamaImage
+        namedType: io.k8s.api.core.v1.LocalObjectReference
+    - name: replicas
       type:
-        scalar: string
+        scalar: numeric
+    - name: server
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.Server
     - name: servicePatches
       type:
         namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.Patches
     - name: statefulSetPatches
       type:
         namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.Patches
-- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ModelStatus
+    - name: storage
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.Storage
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.OllamaServerStatus
   map:
     fields:
     - name: conditions
@@ -163,39 +743,25 @@ var schemaYAML = typed.YAMLObject(`types:
           elementRelationship: associative
           keys:
           - type
-    - name: modelDetails
+    - name: models
       type:
-        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.OllamaModelDetails
+        list:
+          elementType:
+            namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.HostedModel
+          elementRelationship: associative
+          keys:
+          - name
     - name: observedGeneration
       type:
         scalar: numeric
     - name: ollamaImage
       type:
         scalar: string
-- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.OllamaModelDetails
-  map:
-    fields:
-    - name: families
-      type:
-        list:
-          elementType:
-            scalar: string
-          elementRelationship: atomic
-    - name: family
-      type:
-        scalar: string
-    - name: format
-      type:
-        scalar: string
-    - name: parameterSize
-      type:
-        scalar: string
-    - name: parentModel
-      type:
-        scalar: string
-    - name: quantizationLevel
+    - name: readyReplicas
       type:
-        scalar: string
+        scalar: numeric
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.PVCRetentionPolicy
+  scalar: string
 - name: io.aerf.ollama-operator.apis.ollama.v1alpha1.Patches
   map:
     fields:
@@ -208,6 +774,15 @@ var schemaYAML = typed.YAMLObject(`types:
     - name: mergePatch
       type:
         namedType: __untyped_atomic_
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.PersistentVolumeClaimFile
+  map:
+    fields:
+    - name: claimName
+      type:
+        scalar: string
+    - name: path
+      type:
+        scalar: string
 - name: io.aerf.ollama-operator.apis.ollama.v1alpha1.Prompt
   map:
     fields:
@@ -315,8 +890,197 @@ var schemaYAML = typed.YAMLObject(`types:
     - name: response
       type:
         scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.PullFailureReason
+  scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.PullMode
+  scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.PullProgress
+  map:
+    fields:
+    - name: completedBytes
+      type:
+        scalar: numeric
+    - name: digest
+      type:
+        scalar: string
+    - name: estimatedTimeRemaining
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Duration
+    - name: lastStatus
+      type:
+        scalar: string
+    - name: model
+      type:
+        scalar: string
+    - name: percent
+      type:
+        scalar: numeric
+    - name: pod
+      type:
+        scalar: string
+    - name: startTime
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: totalBytes
+      type:
+        scalar: numeric
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.PullState
+  scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.Registry
+  map:
+    fields:
+    - name: credentialsSecretRef
+      type:
+        namedType: io.k8s.api.core.v1.LocalObjectReference
+    - name: insecure
+      type:
+        scalar: boolean
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.ReplicaStatus
+  map:
+    fields:
+    - name: createdModels
+      type:
+        map:
+          elementType:
+            scalar: string
+    - name: message
+      type:
+        scalar: string
+    - name: models
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: atomic
+    - name: pod
+      type:
+        scalar: string
+    - name: ready
+      type:
+        scalar: boolean
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.Server
+  map:
+    fields:
+    - name: contextLength
+      type:
+        scalar: numeric
+    - name: debug
+      type:
+        scalar: boolean
+    - name: flashAttention
+      type:
+        scalar: boolean
+    - name: keepAlive
+      type:
+        scalar: string
+    - name: kvCacheType
+      type:
+        scalar: string
+    - name: maxQueue
+      type:
+        scalar: numeric
+    - name: numParallel
+      type:
+        scalar: numeric
+    - name: origins
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: associative
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.Storage
+  map:
+    fields:
+    - name: accessModes
+      type:
+        list:
+          elementType:
+            namedType: io.k8s.api.core.v1.PersistentVolumeAccessMode
+          elementRelationship: atomic
+    - name: emptyDir
+      type:
+        namedType: io.k8s.api.core.v1.EmptyDirVolumeSource
+    - name: retentionPolicy
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.PVCRetentionPolicy
+    - name: size
+      type:
+        namedType: io.k8s.apimachinery.pkg.api.resource.Quantity
+    - name: storageClassName
+      type:
+        scalar: string
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.UpdatePolicy
+  map:
+    fields:
+    - name: interval
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Duration
+    - name: type
+      type:
+        namedType: io.aerf.ollama-operator.apis.ollama.v1alpha1.UpdatePolicyType
+- name: io.aerf.ollama-operator.apis.ollama.v1alpha1.UpdatePolicyType
+  scalar: string
 - name: io.k8s.api.core.v1.ConditionStatus
   scalar: string
+- name: io.k8s.api.core.v1.ConfigMapKeySelector
+  map:
+    fields:
+    - name: key
+      type:
+        scalar: string
+    - name: name
+      type:
+        scalar: string
+    - name: optional
+      type:
+        scalar: boolean
+    elementRelationship: atomic
+- name: io.k8s.api.core.v1.EmptyDirVolumeSource
+  map:
+    fields:
+    - name: medium
+      type:
+        namedType: io.k8s.api.core.v1.StorageMedium
+    - name: sizeLimit
+      type:
+        namedType: io.k8s.apimachinery.pkg.api.resource.Quantity
+- name: io.k8s.api.core.v1.ImageVolumeSource
+  map:
+    fields:
+    - name: pullPolicy
+      type:
+        namedType: io.k8s.api.core.v1.PullPolicy
+    - name: reference
+      type:
+        scalar: string
+- name: io.k8s.api.core.v1.LocalObjectReference
+  map:
+    fields:
+    - name: name
+      type:
+        scalar: string
+    elementRelationship: atomic
+- name: io.k8s.api.core.v1.PersistentVolumeAccessMode
+  scalar: string
+- name: io.k8s.api.core.v1.PullPolicy
+  scalar: string
+- name: io.k8s.api.core.v1.SecretKeySelector
+  map:
+    fields:
+    - name: key
+      type:
+        scalar: string
+    - name: name
+      type:
+        scalar: string
+    - name: optional
+      type:
+        scalar: boolean
+    elementRelationship: atomic
+- name: io.k8s.api.core.v1.StorageMedium
+  scalar: string
+- name: io.k8s.apimachinery.pkg.api.resource.Quantity
+  scalar: untyped
 - name: io.k8s.apimachinery.pkg.apis.meta.v1.Duration
   scalar: string
 - name: io.k8s.apimachinery.pkg.apis.meta.v1.FieldsV1
@@ -331,6 +1095,38 @@ var schemaYAML = typed.YAMLObject(`types:
         elementType:
           namedType: __untyped_deduced_
         elementRelationship: separable
+- name: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelector
+  map:
+    fields:
+    - name: matchExpressions
+      type:
+        list:
+          elementType:
+            namedType: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelectorRequirement
+          elementRelationship: atomic
+    - name: matchLabels
+      type:
+        map:
+          elementType:
+            scalar: string
+    elementRelationship: atomic
+- name: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelectorOperator
+  scalar: string
+- name: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelectorRequirement
+  map:
+    fields:
+    - name: key
+      type:
+        scalar: string
+    - name: operator
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelectorOperator
+    - name: values
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: atomic
 - name: io.k8s.apimachinery.pkg.apis.meta.v1.ManagedFieldsEntry
   map:
     fields:
@@ -0,0 +1,36 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// APIKeyApplyConfiguration represents a declarative configuration of the APIKey type for use
+// with apply.
+type APIKeyApplyConfiguration struct {
+	// Name of the key in the generated Secret.
+	Name *string `json:"name,omitempty"`
+	// Endpoints the key may call, e.g. /api/generate and /api/chat. A trailing * matches any suffix, e.g. /v1/*.
+	Endpoints []string `json:"endpoints,omitempty"`
+}
+
+// APIKeyApplyConfiguration constructs a declarative configuration of the APIKey type for use with
+// apply.
+func APIKey() *APIKeyApplyConfiguration {
+	return &APIKeyApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *APIKeyApplyConfiguration) WithName(value string) *APIKeyApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithEndpoints adds the given value to the Endpoints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Endpoints field.
+func (b *APIKeyApplyConfiguration) WithEndpoints(values ...string) *APIKeyApplyConfiguration {
+	for i := range values {
+		b.Endpoints = append(b.Endpoints, values[i])
+	}
+	return b
+}
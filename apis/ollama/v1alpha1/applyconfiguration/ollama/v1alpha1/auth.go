@@ -0,0 +1,32 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// AuthApplyConfiguration represents a declarative configuration of the Auth type for use
+// with apply.
+//
+// Auth configures API keys accepted by the auth proxy sidecar. Keys are generated into the <model>-api-keys Secret
+// in the Model's namespace, one data key per APIKey name, and are sent as "Authorization: Bearer <key>".
+// The Secret also holds the operator's key of the Model under ollama-operator, allowed to call every endpoint of this Model only.
+type AuthApplyConfiguration struct {
+	Keys []APIKeyApplyConfiguration `json:"keys,omitempty"`
+}
+
+// AuthApplyConfiguration constructs a declarative configuration of the Auth type for use with
+// apply.
+func Auth() *AuthApplyConfiguration {
+	return &AuthApplyConfiguration{}
+}
+
+// WithKeys adds the given value to the Keys field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Keys field.
+func (b *AuthApplyConfiguration) WithKeys(values ...*APIKeyApplyConfiguration) *AuthApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithKeys")
+		}
+		b.Keys = append(b.Keys, *values[i])
+	}
+	return b
+}
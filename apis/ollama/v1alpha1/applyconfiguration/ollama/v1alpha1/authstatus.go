@@ -0,0 +1,24 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// AuthStatusApplyConfiguration represents a declarative configuration of the AuthStatus type for use
+// with apply.
+type AuthStatusApplyConfiguration struct {
+	// SecretName is the name of the Secret with API keys, stored under their names.
+	SecretName *string `json:"secretName,omitempty"`
+}
+
+// AuthStatusApplyConfiguration constructs a declarative configuration of the AuthStatus type for use with
+// apply.
+func AuthStatus() *AuthStatusApplyConfiguration {
+	return &AuthStatusApplyConfiguration{}
+}
+
+// WithSecretName sets the SecretName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretName field is set to the value of the last call.
+func (b *AuthStatusApplyConfiguration) WithSecretName(value string) *AuthStatusApplyConfiguration {
+	b.SecretName = &value
+	return b
+}
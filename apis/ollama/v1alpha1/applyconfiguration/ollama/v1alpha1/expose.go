@@ -0,0 +1,92 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// ExposeApplyConfiguration represents a declarative configuration of the Expose type for use
+// with apply.
+//
+// Expose configures the Ingress or HTTPRoute generated for the Model. It's named after the Model and routes to its Service,
+// or the one of the OllamaServer the Model references. Requests are forwarded with the path unchanged.
+type ExposeApplyConfiguration struct {
+	// Type of the generated object.
+	Type *ollamav1alpha1.ExposeType `json:"type,omitempty"`
+	// Host the Ollama API is served on, e.g. ollama.example.com. Requests for all hosts are routed when empty.
+	Host *string `json:"host,omitempty"`
+	// Path prefix routed to the Ollama API.
+	Path *string `json:"path,omitempty"`
+	// ClassName is the ingressClassName of the Ingress.
+	ClassName *string `json:"className,omitempty"`
+	// TLSSecretRef references a Secret in the Model's namespace with the TLS certificate of Host, the Ingress terminates TLS with it.
+	TLSSecretRef *v1.LocalObjectReference `json:"tlsSecretRef,omitempty"`
+	// GatewayRef references the Gateway the HTTPRoute is attached to.
+	GatewayRef *GatewayReferenceApplyConfiguration `json:"gatewayRef,omitempty"`
+	// Patches are applied to the generated Ingress or HTTPRoute.
+	Patches *PatchesApplyConfiguration `json:"patches,omitempty"`
+}
+
+// ExposeApplyConfiguration constructs a declarative configuration of the Expose type for use with
+// apply.
+func Expose() *ExposeApplyConfiguration {
+	return &ExposeApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *ExposeApplyConfiguration) WithType(value ollamav1alpha1.ExposeType) *ExposeApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithHost sets the Host field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Host field is set to the value of the last call.
+func (b *ExposeApplyConfiguration) WithHost(value string) *ExposeApplyConfiguration {
+	b.Host = &value
+	return b
+}
+
+// WithPath sets the Path field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Path field is set to the value of the last call.
+func (b *ExposeApplyConfiguration) WithPath(value string) *ExposeApplyConfiguration {
+	b.Path = &value
+	return b
+}
+
+// WithClassName sets the ClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClassName field is set to the value of the last call.
+func (b *ExposeApplyConfiguration) WithClassName(value string) *ExposeApplyConfiguration {
+	b.ClassName = &value
+	return b
+}
+
+// WithTLSSecretRef sets the TLSSecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TLSSecretRef field is set to the value of the last call.
+func (b *ExposeApplyConfiguration) WithTLSSecretRef(value v1.LocalObjectReference) *ExposeApplyConfiguration {
+	b.TLSSecretRef = &value
+	return b
+}
+
+// WithGatewayRef sets the GatewayRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GatewayRef field is set to the value of the last call.
+func (b *ExposeApplyConfiguration) WithGatewayRef(value *GatewayReferenceApplyConfiguration) *ExposeApplyConfiguration {
+	b.GatewayRef = value
+	return b
+}
+
+// WithPatches sets the Patches field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Patches field is set to the value of the last call.
+func (b *ExposeApplyConfiguration) WithPatches(value *PatchesApplyConfiguration) *ExposeApplyConfiguration {
+	b.Patches = value
+	return b
+}
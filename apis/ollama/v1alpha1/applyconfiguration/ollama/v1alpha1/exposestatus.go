@@ -0,0 +1,57 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// ExposeStatusApplyConfiguration represents a declarative configuration of the ExposeStatus type for use
+// with apply.
+type ExposeStatusApplyConfiguration struct {
+	// Kind of the generated object, Ingress or HTTPRoute.
+	Kind *string `json:"kind,omitempty"`
+	// Name of the generated object.
+	Name *string `json:"name,omitempty"`
+	// Addresses assigned to the Ingress by the ingress controller.
+	Addresses []string `json:"addresses,omitempty"`
+	// URL of the Ollama API, known once the Ingress has an address. It's not reported for HTTPRoutes,
+	// the scheme depends on the listener of the Gateway.
+	URL *string `json:"url,omitempty"`
+}
+
+// ExposeStatusApplyConfiguration constructs a declarative configuration of the ExposeStatus type for use with
+// apply.
+func ExposeStatus() *ExposeStatusApplyConfiguration {
+	return &ExposeStatusApplyConfiguration{}
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *ExposeStatusApplyConfiguration) WithKind(value string) *ExposeStatusApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ExposeStatusApplyConfiguration) WithName(value string) *ExposeStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithAddresses adds the given value to the Addresses field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Addresses field.
+func (b *ExposeStatusApplyConfiguration) WithAddresses(values ...string) *ExposeStatusApplyConfiguration {
+	for i := range values {
+		b.Addresses = append(b.Addresses, values[i])
+	}
+	return b
+}
+
+// WithURL sets the URL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the URL field is set to the value of the last call.
+func (b *ExposeStatusApplyConfiguration) WithURL(value string) *ExposeStatusApplyConfiguration {
+	b.URL = &value
+	return b
+}
@@ -0,0 +1,44 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// GatewayReferenceApplyConfiguration represents a declarative configuration of the GatewayReference type for use
+// with apply.
+type GatewayReferenceApplyConfiguration struct {
+	// Name of the Gateway.
+	Name *string `json:"name,omitempty"`
+	// Namespace of the Gateway, defaults to the Model's namespace. The Gateway has to allow routes from it.
+	Namespace *string `json:"namespace,omitempty"`
+	// SectionName selects a listener of the Gateway, all listeners are used when empty.
+	SectionName *string `json:"sectionName,omitempty"`
+}
+
+// GatewayReferenceApplyConfiguration constructs a declarative configuration of the GatewayReference type for use with
+// apply.
+func GatewayReference() *GatewayReferenceApplyConfiguration {
+	return &GatewayReferenceApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *GatewayReferenceApplyConfiguration) WithName(value string) *GatewayReferenceApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *GatewayReferenceApplyConfiguration) WithNamespace(value string) *GatewayReferenceApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithSectionName sets the SectionName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SectionName field is set to the value of the last call.
+func (b *GatewayReferenceApplyConfiguration) WithSectionName(value string) *GatewayReferenceApplyConfiguration {
+	b.SectionName = &value
+	return b
+}
@@ -0,0 +1,46 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// HostedModelApplyConfiguration represents a declarative configuration of the HostedModel type for use
+// with apply.
+type HostedModelApplyConfiguration struct {
+	// Name of the Model in the namespace of the OllamaServer.
+	Name *string `json:"name,omitempty"`
+	// Models served for the Model.
+	Models []string `json:"models,omitempty"`
+	// Ready is true once all models are pulled on all replicas.
+	Ready *bool `json:"ready,omitempty"`
+}
+
+// HostedModelApplyConfiguration constructs a declarative configuration of the HostedModel type for use with
+// apply.
+func HostedModel() *HostedModelApplyConfiguration {
+	return &HostedModelApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *HostedModelApplyConfiguration) WithName(value string) *HostedModelApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithModels adds the given value to the Models field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Models field.
+func (b *HostedModelApplyConfiguration) WithModels(values ...string) *HostedModelApplyConfiguration {
+	for i := range values {
+		b.Models = append(b.Models, values[i])
+	}
+	return b
+}
+
+// WithReady sets the Ready field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ready field is set to the value of the last call.
+func (b *HostedModelApplyConfiguration) WithReady(value bool) *HostedModelApplyConfiguration {
+	b.Ready = &value
+	return b
+}
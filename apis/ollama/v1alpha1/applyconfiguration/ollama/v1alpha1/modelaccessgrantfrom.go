@@ -0,0 +1,24 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// ModelAccessGrantFromApplyConfiguration represents a declarative configuration of the ModelAccessGrantFrom type for use
+// with apply.
+type ModelAccessGrantFromApplyConfiguration struct {
+	// Namespace of the referencing Prompts.
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+// ModelAccessGrantFromApplyConfiguration constructs a declarative configuration of the ModelAccessGrantFrom type for use with
+// apply.
+func ModelAccessGrantFrom() *ModelAccessGrantFromApplyConfiguration {
+	return &ModelAccessGrantFromApplyConfiguration{}
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *ModelAccessGrantFromApplyConfiguration) WithNamespace(value string) *ModelAccessGrantFromApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
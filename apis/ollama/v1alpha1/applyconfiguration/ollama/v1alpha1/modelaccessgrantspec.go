@@ -0,0 +1,48 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// ModelAccessGrantSpecApplyConfiguration represents a declarative configuration of the ModelAccessGrantSpec type for use
+// with apply.
+//
+// ModelAccessGrantSpec defines which namespaces may reference Models in the namespace of the grant.
+// Prompts referencing a Model in another namespace are rejected unless a grant in the Model's namespace allows it,
+// similarly to the ReferenceGrant of Gateway API.
+type ModelAccessGrantSpecApplyConfiguration struct {
+	// From lists namespaces allowed to reference the Models.
+	From []ModelAccessGrantFromApplyConfiguration `json:"from,omitempty"`
+	// To lists the Models that may be referenced.
+	To []ModelAccessGrantToApplyConfiguration `json:"to,omitempty"`
+}
+
+// ModelAccessGrantSpecApplyConfiguration constructs a declarative configuration of the ModelAccessGrantSpec type for use with
+// apply.
+func ModelAccessGrantSpec() *ModelAccessGrantSpecApplyConfiguration {
+	return &ModelAccessGrantSpecApplyConfiguration{}
+}
+
+// WithFrom adds the given value to the From field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the From field.
+func (b *ModelAccessGrantSpecApplyConfiguration) WithFrom(values ...*ModelAccessGrantFromApplyConfiguration) *ModelAccessGrantSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFrom")
+		}
+		b.From = append(b.From, *values[i])
+	}
+	return b
+}
+
+// WithTo adds the given value to the To field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the To field.
+func (b *ModelAccessGrantSpecApplyConfiguration) WithTo(values ...*ModelAccessGrantToApplyConfiguration) *ModelAccessGrantSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTo")
+		}
+		b.To = append(b.To, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,24 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// ModelAccessGrantToApplyConfiguration represents a declarative configuration of the ModelAccessGrantTo type for use
+// with apply.
+type ModelAccessGrantToApplyConfiguration struct {
+	// Name of the Model. All Models in the namespace are granted when empty.
+	Name *string `json:"name,omitempty"`
+}
+
+// ModelAccessGrantToApplyConfiguration constructs a declarative configuration of the ModelAccessGrantTo type for use with
+// apply.
+func ModelAccessGrantTo() *ModelAccessGrantToApplyConfiguration {
+	return &ModelAccessGrantToApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ModelAccessGrantToApplyConfiguration) WithName(value string) *ModelAccessGrantToApplyConfiguration {
+	b.Name = &value
+	return b
+}
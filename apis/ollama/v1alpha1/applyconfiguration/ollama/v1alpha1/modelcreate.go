@@ -0,0 +1,134 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// ModelCreateApplyConfiguration represents a declarative configuration of the ModelCreate type for use
+// with apply.
+//
+// ModelCreate is a Modelfile, see https://github.com/ollama/ollama/blob/main/docs/modelfile.md.
+// It's either set inline or read from a ConfigMap. The model is re-created whenever it changes.
+type ModelCreateApplyConfiguration struct {
+	// From is the base model like llama3.1:8b, it's pulled if it's missing.
+	From *string `json:"from,omitempty"`
+	// Files are GGUF or safetensors weights the model is created from instead of a base model, e.g. for air-gapped clusters.
+	Files []ModelFileApplyConfiguration `json:"files,omitempty"`
+	// Adapters are LoRA adapters applied to the model.
+	Adapters []ModelFileApplyConfiguration `json:"adapters,omitempty"`
+	// System message of the model.
+	System *string `json:"system,omitempty"`
+	// Template is the full prompt template of the model.
+	Template *string `json:"template,omitempty"`
+	// Parameters like temperature or num_ctx. A parameter can be repeated, e.g. stop.
+	Parameters []ModelParameterApplyConfiguration `json:"parameters,omitempty"`
+	// Messages build up the conversation history the model starts with.
+	Messages []ModelMessageApplyConfiguration `json:"messages,omitempty"`
+	// License of the model.
+	License []string `json:"license,omitempty"`
+	// ModelfileRef references a key of a ConfigMap in the Model's namespace holding a Modelfile.
+	// Changes of the ConfigMap are picked up within a minute.
+	ModelfileRef *v1.ConfigMapKeySelector `json:"modelfileRef,omitempty"`
+}
+
+// ModelCreateApplyConfiguration constructs a declarative configuration of the ModelCreate type for use with
+// apply.
+func ModelCreate() *ModelCreateApplyConfiguration {
+	return &ModelCreateApplyConfiguration{}
+}
+
+// WithFrom sets the From field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the From field is set to the value of the last call.
+func (b *ModelCreateApplyConfiguration) WithFrom(value string) *ModelCreateApplyConfiguration {
+	b.From = &value
+	return b
+}
+
+// WithFiles adds the given value to the Files field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Files field.
+func (b *ModelCreateApplyConfiguration) WithFiles(values ...*ModelFileApplyConfiguration) *ModelCreateApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFiles")
+		}
+		b.Files = append(b.Files, *values[i])
+	}
+	return b
+}
+
+// WithAdapters adds the given value to the Adapters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Adapters field.
+func (b *ModelCreateApplyConfiguration) WithAdapters(values ...*ModelFileApplyConfiguration) *ModelCreateApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAdapters")
+		}
+		b.Adapters = append(b.Adapters, *values[i])
+	}
+	return b
+}
+
+// WithSystem sets the System field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the System field is set to the value of the last call.
+func (b *ModelCreateApplyConfiguration) WithSystem(value string) *ModelCreateApplyConfiguration {
+	b.System = &value
+	return b
+}
+
+// WithTemplate sets the Template field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Template field is set to the value of the last call.
+func (b *ModelCreateApplyConfiguration) WithTemplate(value string) *ModelCreateApplyConfiguration {
+	b.Template = &value
+	return b
+}
+
+// WithParameters adds the given value to the Parameters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Parameters field.
+func (b *ModelCreateApplyConfiguration) WithParameters(values ...*ModelParameterApplyConfiguration) *ModelCreateApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithParameters")
+		}
+		b.Parameters = append(b.Parameters, *values[i])
+	}
+	return b
+}
+
+// WithMessages adds the given value to the Messages field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Messages field.
+func (b *ModelCreateApplyConfiguration) WithMessages(values ...*ModelMessageApplyConfiguration) *ModelCreateApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMessages")
+		}
+		b.Messages = append(b.Messages, *values[i])
+	}
+	return b
+}
+
+// WithLicense adds the given value to the License field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the License field.
+func (b *ModelCreateApplyConfiguration) WithLicense(values ...string) *ModelCreateApplyConfiguration {
+	for i := range values {
+		b.License = append(b.License, values[i])
+	}
+	return b
+}
+
+// WithModelfileRef sets the ModelfileRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ModelfileRef field is set to the value of the last call.
+func (b *ModelCreateApplyConfiguration) WithModelfileRef(value v1.ConfigMapKeySelector) *ModelCreateApplyConfiguration {
+	b.ModelfileRef = &value
+	return b
+}
@@ -0,0 +1,45 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// ModelEntryApplyConfiguration represents a declarative configuration of the ModelEntry type for use
+// with apply.
+type ModelEntryApplyConfiguration struct {
+	// Name of the model like phi3, llama3.1 etc
+	Name *string `json:"name,omitempty"`
+	// Digest pins the expected digest of the model, as reported by `ollama list`, e.g. sha256:4f2222927938 or its full form.
+	// Tags are mutable, so the digest of the pulled model is verified and a mismatch stalls the Model.
+	Digest *string `json:"digest,omitempty"`
+	// Create builds the model from a Modelfile with Ollama's create API instead of pulling it from the registry.
+	Create *ModelCreateApplyConfiguration `json:"create,omitempty"`
+}
+
+// ModelEntryApplyConfiguration constructs a declarative configuration of the ModelEntry type for use with
+// apply.
+func ModelEntry() *ModelEntryApplyConfiguration {
+	return &ModelEntryApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ModelEntryApplyConfiguration) WithName(value string) *ModelEntryApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithDigest sets the Digest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Digest field is set to the value of the last call.
+func (b *ModelEntryApplyConfiguration) WithDigest(value string) *ModelEntryApplyConfiguration {
+	b.Digest = &value
+	return b
+}
+
+// WithCreate sets the Create field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Create field is set to the value of the last call.
+func (b *ModelEntryApplyConfiguration) WithCreate(value *ModelCreateApplyConfiguration) *ModelEntryApplyConfiguration {
+	b.Create = value
+	return b
+}
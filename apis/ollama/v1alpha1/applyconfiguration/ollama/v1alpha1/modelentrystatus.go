@@ -0,0 +1,198 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ModelEntryStatusApplyConfiguration represents a declarative configuration of the ModelEntryStatus type for use
+// with apply.
+type ModelEntryStatusApplyConfiguration struct {
+	Name *string `json:"name,omitempty"`
+	// Ready is true once the model has been pulled and its details were fetched from Ollama.
+	Ready     *bool                                 `json:"ready,omitempty"`
+	PullState *ollamav1alpha1.PullState             `json:"pullState,omitempty"`
+	Message   *string                               `json:"message,omitempty"`
+	Details   *OllamaModelDetailsApplyConfiguration `json:"details,omitempty"`
+	// PullAttempts is the number of failed attempts to pull the model since the last success or spec change.
+	PullAttempts *int32 `json:"pullAttempts,omitempty"`
+	// LastPullError is the error of the last failed pull attempt.
+	LastPullError *string `json:"lastPullError,omitempty"`
+	// FailureReason is set when the last pull failure needs a human intervention.
+	FailureReason *ollamav1alpha1.PullFailureReason `json:"failureReason,omitempty"`
+	// FailedGeneration is the generation of the Model the last pull failure was observed at. Failed pulls are retried
+	// right away once the spec changes.
+	FailedGeneration *int64 `json:"failedGeneration,omitempty"`
+	// NextPullAttemptTime is the earliest time the failed pull is retried.
+	NextPullAttemptTime *v1.Time `json:"nextPullAttemptTime,omitempty"`
+	// Registry the model is resolved from, e.g. https://registry.ollama.ai.
+	Registry *string `json:"registry,omitempty"`
+	// Digest of the model reported by Ollama. Created models get a new digest whenever they're re-created.
+	Digest *string `json:"digest,omitempty"`
+	// Size of the model on disk in bytes.
+	Size *int64 `json:"size,omitempty"`
+	// ModifiedAt is the time the model was last pulled or created, as reported by Ollama.
+	ModifiedAt *v1.Time `json:"modifiedAt,omitempty"`
+	// CreateHash is the hash of the Modelfile the model was created from on all replicas.
+	CreateHash *string `json:"createHash,omitempty"`
+	// SeededFrom is the spec.source the model was found in instead of being pulled, e.g. "snapshot phi3-weights".
+	// Seeded models are neither updated nor deleted by the operator. With spec.pullMode InitContainer only models from
+	// an image volume are reported as seeded, the ones restored from a snapshot can't be told apart from the pulled ones.
+	SeededFrom *string `json:"seededFrom,omitempty"`
+	// Load reports whether the model is loaded into memory, as reported by Ollama's running models endpoint.
+	Load *ModelLoadStatusApplyConfiguration `json:"load,omitempty"`
+	// Update reports re-pulls of the model done according to spec.updatePolicy.
+	Update *ModelUpdateStatusApplyConfiguration `json:"update,omitempty"`
+}
+
+// ModelEntryStatusApplyConfiguration constructs a declarative configuration of the ModelEntryStatus type for use with
+// apply.
+func ModelEntryStatus() *ModelEntryStatusApplyConfiguration {
+	return &ModelEntryStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithName(value string) *ModelEntryStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithReady sets the Ready field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ready field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithReady(value bool) *ModelEntryStatusApplyConfiguration {
+	b.Ready = &value
+	return b
+}
+
+// WithPullState sets the PullState field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PullState field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithPullState(value ollamav1alpha1.PullState) *ModelEntryStatusApplyConfiguration {
+	b.PullState = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithMessage(value string) *ModelEntryStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithDetails sets the Details field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Details field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithDetails(value *OllamaModelDetailsApplyConfiguration) *ModelEntryStatusApplyConfiguration {
+	b.Details = value
+	return b
+}
+
+// WithPullAttempts sets the PullAttempts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PullAttempts field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithPullAttempts(value int32) *ModelEntryStatusApplyConfiguration {
+	b.PullAttempts = &value
+	return b
+}
+
+// WithLastPullError sets the LastPullError field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastPullError field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithLastPullError(value string) *ModelEntryStatusApplyConfiguration {
+	b.LastPullError = &value
+	return b
+}
+
+// WithFailureReason sets the FailureReason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailureReason field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithFailureReason(value ollamav1alpha1.PullFailureReason) *ModelEntryStatusApplyConfiguration {
+	b.FailureReason = &value
+	return b
+}
+
+// WithFailedGeneration sets the FailedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailedGeneration field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithFailedGeneration(value int64) *ModelEntryStatusApplyConfiguration {
+	b.FailedGeneration = &value
+	return b
+}
+
+// WithNextPullAttemptTime sets the NextPullAttemptTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NextPullAttemptTime field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithNextPullAttemptTime(value v1.Time) *ModelEntryStatusApplyConfiguration {
+	b.NextPullAttemptTime = &value
+	return b
+}
+
+// WithRegistry sets the Registry field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Registry field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithRegistry(value string) *ModelEntryStatusApplyConfiguration {
+	b.Registry = &value
+	return b
+}
+
+// WithDigest sets the Digest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Digest field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithDigest(value string) *ModelEntryStatusApplyConfiguration {
+	b.Digest = &value
+	return b
+}
+
+// WithSize sets the Size field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Size field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithSize(value int64) *ModelEntryStatusApplyConfiguration {
+	b.Size = &value
+	return b
+}
+
+// WithModifiedAt sets the ModifiedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ModifiedAt field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithModifiedAt(value v1.Time) *ModelEntryStatusApplyConfiguration {
+	b.ModifiedAt = &value
+	return b
+}
+
+// WithCreateHash sets the CreateHash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CreateHash field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithCreateHash(value string) *ModelEntryStatusApplyConfiguration {
+	b.CreateHash = &value
+	return b
+}
+
+// WithSeededFrom sets the SeededFrom field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SeededFrom field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithSeededFrom(value string) *ModelEntryStatusApplyConfiguration {
+	b.SeededFrom = &value
+	return b
+}
+
+// WithLoad sets the Load field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Load field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithLoad(value *ModelLoadStatusApplyConfiguration) *ModelEntryStatusApplyConfiguration {
+	b.Load = value
+	return b
+}
+
+// WithUpdate sets the Update field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Update field is set to the value of the last call.
+func (b *ModelEntryStatusApplyConfiguration) WithUpdate(value *ModelUpdateStatusApplyConfiguration) *ModelEntryStatusApplyConfiguration {
+	b.Update = value
+	return b
+}
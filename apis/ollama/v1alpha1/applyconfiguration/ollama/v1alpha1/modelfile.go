@@ -0,0 +1,73 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// ModelFileApplyConfiguration represents a declarative configuration of the ModelFile type for use
+// with apply.
+//
+// ModelFile is a file uploaded to Ollama with the blob API before the model is created.
+// Upload progress is reported in status.pull like the one of pulls.
+type ModelFileApplyConfiguration struct {
+	// Name of the file in the model, e.g. model.gguf or adapter.safetensors.
+	Name *string `json:"name,omitempty"`
+	// Digest is the expected digest of the file like sha256:4f1c..., the model is not created if the file doesn't match it.
+	Digest *string `json:"digest,omitempty"`
+	// ConfigMapKeyRef selects a key of a ConfigMap in the Model's namespace. binaryData takes precedence over data.
+	ConfigMapKeyRef *v1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// SecretKeyRef selects a key of a Secret in the Model's namespace.
+	SecretKeyRef *v1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+	// PersistentVolumeClaim selects a file on a PVC in the Model's namespace. The PVC is mounted read-only into a blob-server
+	// sidecar of every Ollama pod, which serves the file to the operator, so its access modes must allow that, e.g. ReadOnlyMany.
+	// The sidecar requires a token generated by the operator in the <model name>-blob-server Secret.
+	PersistentVolumeClaim *PersistentVolumeClaimFileApplyConfiguration `json:"persistentVolumeClaim,omitempty"`
+}
+
+// ModelFileApplyConfiguration constructs a declarative configuration of the ModelFile type for use with
+// apply.
+func ModelFile() *ModelFileApplyConfiguration {
+	return &ModelFileApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ModelFileApplyConfiguration) WithName(value string) *ModelFileApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithDigest sets the Digest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Digest field is set to the value of the last call.
+func (b *ModelFileApplyConfiguration) WithDigest(value string) *ModelFileApplyConfiguration {
+	b.Digest = &value
+	return b
+}
+
+// WithConfigMapKeyRef sets the ConfigMapKeyRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConfigMapKeyRef field is set to the value of the last call.
+func (b *ModelFileApplyConfiguration) WithConfigMapKeyRef(value v1.ConfigMapKeySelector) *ModelFileApplyConfiguration {
+	b.ConfigMapKeyRef = &value
+	return b
+}
+
+// WithSecretKeyRef sets the SecretKeyRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretKeyRef field is set to the value of the last call.
+func (b *ModelFileApplyConfiguration) WithSecretKeyRef(value v1.SecretKeySelector) *ModelFileApplyConfiguration {
+	b.SecretKeyRef = &value
+	return b
+}
+
+// WithPersistentVolumeClaim sets the PersistentVolumeClaim field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PersistentVolumeClaim field is set to the value of the last call.
+func (b *ModelFileApplyConfiguration) WithPersistentVolumeClaim(value *PersistentVolumeClaimFileApplyConfiguration) *ModelFileApplyConfiguration {
+	b.PersistentVolumeClaim = value
+	return b
+}
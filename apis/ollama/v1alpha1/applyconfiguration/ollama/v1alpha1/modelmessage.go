@@ -0,0 +1,32 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// ModelMessageApplyConfiguration represents a declarative configuration of the ModelMessage type for use
+// with apply.
+type ModelMessageApplyConfiguration struct {
+	Role    *string `json:"role,omitempty"`
+	Content *string `json:"content,omitempty"`
+}
+
+// ModelMessageApplyConfiguration constructs a declarative configuration of the ModelMessage type for use with
+// apply.
+func ModelMessage() *ModelMessageApplyConfiguration {
+	return &ModelMessageApplyConfiguration{}
+}
+
+// WithRole sets the Role field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Role field is set to the value of the last call.
+func (b *ModelMessageApplyConfiguration) WithRole(value string) *ModelMessageApplyConfiguration {
+	b.Role = &value
+	return b
+}
+
+// WithContent sets the Content field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Content field is set to the value of the last call.
+func (b *ModelMessageApplyConfiguration) WithContent(value string) *ModelMessageApplyConfiguration {
+	b.Content = &value
+	return b
+}
@@ -0,0 +1,34 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// ModelParameterApplyConfiguration represents a declarative configuration of the ModelParameter type for use
+// with apply.
+type ModelParameterApplyConfiguration struct {
+	// Name of the parameter, e.g. temperature.
+	Name *string `json:"name,omitempty"`
+	// Value of the parameter, e.g. "0.7".
+	Value *string `json:"value,omitempty"`
+}
+
+// ModelParameterApplyConfiguration constructs a declarative configuration of the ModelParameter type for use with
+// apply.
+func ModelParameter() *ModelParameterApplyConfiguration {
+	return &ModelParameterApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ModelParameterApplyConfiguration) WithName(value string) *ModelParameterApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithValue sets the Value field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Value field is set to the value of the last call.
+func (b *ModelParameterApplyConfiguration) WithValue(value string) *ModelParameterApplyConfiguration {
+	b.Value = &value
+	return b
+}
@@ -8,6 +8,8 @@ type ModelRefApplyConfiguration struct {
 	Name *string `json:"name,omitempty"`
 	// defaults to prompt namespace
 	Namespace *string `json:"namespace,omitempty"`
+	// Model selects one of the models served by the referenced Model, e.g. phi3. Defaults to the first one.
+	Model *string `json:"model,omitempty"`
 }
 
 // ModelRefApplyConfiguration constructs a declarative configuration of the ModelRef type for use with
@@ -31,3 +33,11 @@ func (b *ModelRefApplyConfiguration) WithNamespace(value string) *ModelRefApplyC
 	b.Namespace = &value
 	return b
 }
+
+// WithModel sets the Model field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Model field is set to the value of the last call.
+func (b *ModelRefApplyConfiguration) WithModel(value string) *ModelRefApplyConfiguration {
+	b.Model = &value
+	return b
+}
@@ -0,0 +1,43 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// ModelSourceApplyConfiguration represents a declarative configuration of the ModelSource type for use
+// with apply.
+//
+// ModelSource is where Ollama's models directory, with its blobs and manifests, is seeded from.
+type ModelSourceApplyConfiguration struct {
+	// ImageVolume mounts an OCI image or artifact read-only over the models directory, it requires the ImageVolume
+	// feature of Kubernetes. Models missing in the image can't be pulled or created, they're reported as stalled
+	// with the NotInSeedImage reason.
+	ImageVolume *v1.ImageVolumeSource `json:"imageVolume,omitempty"`
+	// Snapshot references a VolumeSnapshot in the Model's namespace the PVCs are restored from. It can't be changed
+	// once set, volume claim templates of the StatefulSet are immutable.
+	Snapshot *v1.LocalObjectReference `json:"snapshot,omitempty"`
+}
+
+// ModelSourceApplyConfiguration constructs a declarative configuration of the ModelSource type for use with
+// apply.
+func ModelSource() *ModelSourceApplyConfiguration {
+	return &ModelSourceApplyConfiguration{}
+}
+
+// WithImageVolume sets the ImageVolume field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ImageVolume field is set to the value of the last call.
+func (b *ModelSourceApplyConfiguration) WithImageVolume(value v1.ImageVolumeSource) *ModelSourceApplyConfiguration {
+	b.ImageVolume = &value
+	return b
+}
+
+// WithSnapshot sets the Snapshot field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Snapshot field is set to the value of the last call.
+func (b *ModelSourceApplyConfiguration) WithSnapshot(value v1.LocalObjectReference) *ModelSourceApplyConfiguration {
+	b.Snapshot = &value
+	return b
+}
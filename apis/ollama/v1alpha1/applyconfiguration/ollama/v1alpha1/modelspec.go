@@ -2,6 +2,12 @@
 
 package v1alpha1
 
+import (
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // ModelSpecApplyConfiguration represents a declarative configuration of the ModelSpec type for use
 // with apply.
 //
@@ -9,8 +15,56 @@ package v1alpha1
 type ModelSpecApplyConfiguration struct {
 	// https://hub.docker.com/r/ollama/ollama/tags
 	OllamaImage *string `json:"ollamaImage,omitempty"`
-	// Model like phi3, llama3.1 etc
-	Model              *string                    `json:"model,omitempty"`
+	// Model like phi3, llama3.1 etc. Shorthand for a single entry in Models.
+	Model *string `json:"model,omitempty"`
+	// Digest pins the expected digest of Model. See ModelEntry.Digest.
+	Digest *string `json:"digest,omitempty"`
+	// Models served by the same Ollama server. If Model is set as well it's served alongside those.
+	Models []ModelEntryApplyConfiguration `json:"models,omitempty"`
+	// Create builds Model from a Modelfile with Ollama's create API instead of pulling it from the registry.
+	Create *ModelCreateApplyConfiguration `json:"create,omitempty"`
+	// ServerRef references an OllamaServer in the Model's namespace the models are pulled onto, instead of running
+	// a dedicated Ollama StatefulSet for the Model. Replicas are ignored then, the server's ones are used.
+	ServerRef *v1.LocalObjectReference `json:"serverRef,omitempty"`
+	// Replicas is the number of Ollama pods. Every replica pulls the models into its own volume.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Storage configures the volume holding pulled models.
+	Storage *StorageApplyConfiguration `json:"storage,omitempty"`
+	// Registry configures how models are pulled from the registry. The registry itself is part of the model name,
+	// e.g. registry.example.com/library/phi3:latest, and defaults to registry.ollama.ai.
+	Registry *RegistryApplyConfiguration `json:"registry,omitempty"`
+	// Source seeds Ollama's models directory, so that models found in it are not pulled from the registry.
+	Source *ModelSourceApplyConfiguration `json:"source,omitempty"`
+	// PullMode decides how models are pulled, defaults to Operator. With InitContainer the pods pull the models
+	// in an init container running a temporary Ollama server, so the operator doesn't have to reach Ollama for the
+	// pulls and only verifies the models. Created models are always created by the operator.
+	PullMode *ollamav1alpha1.PullMode `json:"pullMode,omitempty"`
+	// UpdatePolicy configures re-pulling of models to pick up changes of moving tags like llama3.1.
+	// Created models and models with a pinned digest are never updated. It can't be combined with pullMode InitContainer.
+	UpdatePolicy *UpdatePolicyApplyConfiguration `json:"updatePolicy,omitempty"`
+	// Idle scales the StatefulSet to zero once the Model wasn't used for this long, e.g. 24h. The volume is kept, so
+	// the models don't have to be pulled again. Prompts and models loaded in Ollama count as use, a new Prompt referencing
+	// the Model scales it back up. Ollama unloads models after spec.server.keepAlive, 5m by default with Idle set.
+	Idle *metav1.Duration `json:"idle,omitempty"`
+	// Suspend scales the StatefulSet to zero without deleting the Model, its volume or generated objects.
+	Suspend *bool `json:"suspend,omitempty"`
+	// WarmUp loads the models into memory on every replica before the Model is reported Ready,
+	// so that the first Prompt doesn't pay the load time.
+	WarmUp *bool `json:"warmUp,omitempty"`
+	// Server configures the Ollama server. Env vars set with statefulSetPatches take precedence over the fields set here,
+	// conflicts are reported in the ServerConfigured condition.
+	Server *ServerApplyConfiguration `json:"server,omitempty"`
+	// NetworkPolicy generates a NetworkPolicy allowing ingress traffic to the Ollama pods only from the operator and the listed peers.
+	NetworkPolicy *NetworkPolicyApplyConfiguration `json:"networkPolicy,omitempty"`
+	// Auth puts a reverse proxy checking API keys in front of Ollama. The Service routes through it, Ollama itself
+	// listens only on localhost then.
+	Auth *AuthApplyConfiguration `json:"auth,omitempty"`
+	// Expose routes traffic from outside of the cluster to the Ollama API of the Model with an Ingress or a Gateway API HTTPRoute.
+	// It requires auth, so it can't be used by Models referencing an OllamaServer, which doesn't support it.
+	Expose *ExposeApplyConfiguration `json:"expose,omitempty"`
+	// CleanupOnDelete adds a finalizer that deletes the pulled models from Ollama before the Model is removed.
+	// Useful when the volume outlives the Model.
+	CleanupOnDelete    *bool                      `json:"cleanupOnDelete,omitempty"`
 	StatefulSetPatches *PatchesApplyConfiguration `json:"statefulSetPatches,omitempty"`
 	ServicePatches     *PatchesApplyConfiguration `json:"servicePatches,omitempty"`
 }
@@ -37,6 +91,155 @@ func (b *ModelSpecApplyConfiguration) WithModel(value string) *ModelSpecApplyCon
 	return b
 }
 
+// WithDigest sets the Digest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Digest field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithDigest(value string) *ModelSpecApplyConfiguration {
+	b.Digest = &value
+	return b
+}
+
+// WithModels adds the given value to the Models field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Models field.
+func (b *ModelSpecApplyConfiguration) WithModels(values ...*ModelEntryApplyConfiguration) *ModelSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithModels")
+		}
+		b.Models = append(b.Models, *values[i])
+	}
+	return b
+}
+
+// WithCreate sets the Create field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Create field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithCreate(value *ModelCreateApplyConfiguration) *ModelSpecApplyConfiguration {
+	b.Create = value
+	return b
+}
+
+// WithServerRef sets the ServerRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServerRef field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithServerRef(value v1.LocalObjectReference) *ModelSpecApplyConfiguration {
+	b.ServerRef = &value
+	return b
+}
+
+// WithReplicas sets the Replicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replicas field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithReplicas(value int32) *ModelSpecApplyConfiguration {
+	b.Replicas = &value
+	return b
+}
+
+// WithStorage sets the Storage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Storage field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithStorage(value *StorageApplyConfiguration) *ModelSpecApplyConfiguration {
+	b.Storage = value
+	return b
+}
+
+// WithRegistry sets the Registry field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Registry field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithRegistry(value *RegistryApplyConfiguration) *ModelSpecApplyConfiguration {
+	b.Registry = value
+	return b
+}
+
+// WithSource sets the Source field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Source field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithSource(value *ModelSourceApplyConfiguration) *ModelSpecApplyConfiguration {
+	b.Source = value
+	return b
+}
+
+// WithPullMode sets the PullMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PullMode field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithPullMode(value ollamav1alpha1.PullMode) *ModelSpecApplyConfiguration {
+	b.PullMode = &value
+	return b
+}
+
+// WithUpdatePolicy sets the UpdatePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdatePolicy field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithUpdatePolicy(value *UpdatePolicyApplyConfiguration) *ModelSpecApplyConfiguration {
+	b.UpdatePolicy = value
+	return b
+}
+
+// WithIdle sets the Idle field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Idle field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithIdle(value metav1.Duration) *ModelSpecApplyConfiguration {
+	b.Idle = &value
+	return b
+}
+
+// WithSuspend sets the Suspend field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Suspend field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithSuspend(value bool) *ModelSpecApplyConfiguration {
+	b.Suspend = &value
+	return b
+}
+
+// WithWarmUp sets the WarmUp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WarmUp field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithWarmUp(value bool) *ModelSpecApplyConfiguration {
+	b.WarmUp = &value
+	return b
+}
+
+// WithServer sets the Server field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Server field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithServer(value *ServerApplyConfiguration) *ModelSpecApplyConfiguration {
+	b.Server = value
+	return b
+}
+
+// WithNetworkPolicy sets the NetworkPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NetworkPolicy field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithNetworkPolicy(value *NetworkPolicyApplyConfiguration) *ModelSpecApplyConfiguration {
+	b.NetworkPolicy = value
+	return b
+}
+
+// WithAuth sets the Auth field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Auth field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithAuth(value *AuthApplyConfiguration) *ModelSpecApplyConfiguration {
+	b.Auth = value
+	return b
+}
+
+// WithExpose sets the Expose field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Expose field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithExpose(value *ExposeApplyConfiguration) *ModelSpecApplyConfiguration {
+	b.Expose = value
+	return b
+}
+
+// WithCleanupOnDelete sets the CleanupOnDelete field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CleanupOnDelete field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithCleanupOnDelete(value bool) *ModelSpecApplyConfiguration {
+	b.CleanupOnDelete = &value
+	return b
+}
+
 // WithStatefulSetPatches sets the StatefulSetPatches field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the StatefulSetPatches field is set to the value of the last call.
@@ -3,7 +3,9 @@
 package v1alpha1
 
 import (
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
 	v2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ModelStatusApplyConfiguration represents a declarative configuration of the ModelStatus type for use
@@ -15,9 +17,35 @@ type ModelStatusApplyConfiguration struct {
 	// ObservedGeneration is the latest metadata.generation
 	// which resulted in either a ready state, or stalled due to error
 	// it can not recover from without human intervention.
-	ObservedGeneration *int64                                `json:"observedGeneration,omitempty"`
-	OllamaImage        *string                               `json:"ollamaImage,omitempty"`
+	ObservedGeneration *int64  `json:"observedGeneration,omitempty"`
+	OllamaImage        *string `json:"ollamaImage,omitempty"`
+	// OllamaModelDetails are the details of the first model from spec, kept for compatibility with single-model Models.
+	// See Models for details of every model.
 	OllamaModelDetails *OllamaModelDetailsApplyConfiguration `json:"modelDetails,omitempty"`
+	// Models reports the state of every model from spec.model and spec.models.
+	Models []ModelEntryStatusApplyConfiguration `json:"models,omitempty"`
+	// Pull reports the progress of the last model pull.
+	Pull *PullProgressApplyConfiguration `json:"pull,omitempty"`
+	// PulledModels lists models pulled by the operator. Models that are no longer desired are deleted from Ollama
+	// and removed from this list.
+	PulledModels []string `json:"pulledModels,omitempty"`
+	// ReadyReplicas is the number of pods that have all models pulled.
+	ReadyReplicas *int32 `json:"readyReplicas,omitempty"`
+	// Replicas reports the state of every Ollama pod.
+	Replicas []ReplicaStatusApplyConfiguration `json:"replicas,omitempty"`
+	// Expose reports the object generated from spec.expose, its readiness is reported in the Exposed condition.
+	Expose *ExposeStatusApplyConfiguration `json:"expose,omitempty"`
+	// LastUsedTime is when the Model was last used, tracked for spec.idle.
+	LastUsedTime *v1.Time `json:"lastUsedTime,omitempty"`
+	// ScaledToZero is true while the StatefulSet is scaled to zero because of spec.idle.
+	ScaledToZero *bool `json:"scaledToZero,omitempty"`
+	// RetentionPolicy reports what happens to the PVCs once the Model is deleted, it's empty if the Model has no PVCs.
+	RetentionPolicy *ollamav1alpha1.PVCRetentionPolicy `json:"retentionPolicy,omitempty"`
+	// PendingChanges summarizes what the controller would change in generated objects, while reconciliation
+	// is paused with ModelPausedAnnotation.
+	PendingChanges []string `json:"pendingChanges,omitempty"`
+	// Auth reports the Secret with API keys generated for spec.auth.
+	Auth *AuthStatusApplyConfiguration `json:"auth,omitempty"`
 }
 
 // ModelStatusApplyConfiguration constructs a declarative configuration of the ModelStatus type for use with
@@ -59,3 +87,105 @@ func (b *ModelStatusApplyConfiguration) WithOllamaModelDetails(value *OllamaMode
 	b.OllamaModelDetails = value
 	return b
 }
+
+// WithModels adds the given value to the Models field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Models field.
+func (b *ModelStatusApplyConfiguration) WithModels(values ...*ModelEntryStatusApplyConfiguration) *ModelStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithModels")
+		}
+		b.Models = append(b.Models, *values[i])
+	}
+	return b
+}
+
+// WithPull sets the Pull field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Pull field is set to the value of the last call.
+func (b *ModelStatusApplyConfiguration) WithPull(value *PullProgressApplyConfiguration) *ModelStatusApplyConfiguration {
+	b.Pull = value
+	return b
+}
+
+// WithPulledModels adds the given value to the PulledModels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PulledModels field.
+func (b *ModelStatusApplyConfiguration) WithPulledModels(values ...string) *ModelStatusApplyConfiguration {
+	for i := range values {
+		b.PulledModels = append(b.PulledModels, values[i])
+	}
+	return b
+}
+
+// WithReadyReplicas sets the ReadyReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadyReplicas field is set to the value of the last call.
+func (b *ModelStatusApplyConfiguration) WithReadyReplicas(value int32) *ModelStatusApplyConfiguration {
+	b.ReadyReplicas = &value
+	return b
+}
+
+// WithReplicas adds the given value to the Replicas field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Replicas field.
+func (b *ModelStatusApplyConfiguration) WithReplicas(values ...*ReplicaStatusApplyConfiguration) *ModelStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithReplicas")
+		}
+		b.Replicas = append(b.Replicas, *values[i])
+	}
+	return b
+}
+
+// WithExpose sets the Expose field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Expose field is set to the value of the last call.
+func (b *ModelStatusApplyConfiguration) WithExpose(value *ExposeStatusApplyConfiguration) *ModelStatusApplyConfiguration {
+	b.Expose = value
+	return b
+}
+
+// WithLastUsedTime sets the LastUsedTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUsedTime field is set to the value of the last call.
+func (b *ModelStatusApplyConfiguration) WithLastUsedTime(value v1.Time) *ModelStatusApplyConfiguration {
+	b.LastUsedTime = &value
+	return b
+}
+
+// WithScaledToZero sets the ScaledToZero field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaledToZero field is set to the value of the last call.
+func (b *ModelStatusApplyConfiguration) WithScaledToZero(value bool) *ModelStatusApplyConfiguration {
+	b.ScaledToZero = &value
+	return b
+}
+
+// WithRetentionPolicy sets the RetentionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetentionPolicy field is set to the value of the last call.
+func (b *ModelStatusApplyConfiguration) WithRetentionPolicy(value ollamav1alpha1.PVCRetentionPolicy) *ModelStatusApplyConfiguration {
+	b.RetentionPolicy = &value
+	return b
+}
+
+// WithPendingChanges adds the given value to the PendingChanges field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PendingChanges field.
+func (b *ModelStatusApplyConfiguration) WithPendingChanges(values ...string) *ModelStatusApplyConfiguration {
+	for i := range values {
+		b.PendingChanges = append(b.PendingChanges, values[i])
+	}
+	return b
+}
+
+// WithAuth sets the Auth field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Auth field is set to the value of the last call.
+func (b *ModelStatusApplyConfiguration) WithAuth(value *AuthStatusApplyConfiguration) *ModelStatusApplyConfiguration {
+	b.Auth = value
+	return b
+}
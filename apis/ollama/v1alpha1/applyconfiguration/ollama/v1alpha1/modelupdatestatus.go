@@ -0,0 +1,58 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ModelUpdateStatusApplyConfiguration represents a declarative configuration of the ModelUpdateStatus type for use
+// with apply.
+type ModelUpdateStatusApplyConfiguration struct {
+	// LastCheckTime is the time the model was last re-pulled on all replicas, or pulled if it wasn't re-pulled yet.
+	LastCheckTime *v1.Time `json:"lastCheckTime,omitempty"`
+	// LastUpdateTime is the time a re-pull last changed the digest of the model.
+	LastUpdateTime *v1.Time `json:"lastUpdateTime,omitempty"`
+	// PreviousDigest is the digest of the model before the last update, the current one is reported in digest.
+	PreviousDigest *string `json:"previousDigest,omitempty"`
+	// LastError is the error of the last failed re-pull. The previous version of the model is kept.
+	LastError *string `json:"lastError,omitempty"`
+}
+
+// ModelUpdateStatusApplyConfiguration constructs a declarative configuration of the ModelUpdateStatus type for use with
+// apply.
+func ModelUpdateStatus() *ModelUpdateStatusApplyConfiguration {
+	return &ModelUpdateStatusApplyConfiguration{}
+}
+
+// WithLastCheckTime sets the LastCheckTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastCheckTime field is set to the value of the last call.
+func (b *ModelUpdateStatusApplyConfiguration) WithLastCheckTime(value v1.Time) *ModelUpdateStatusApplyConfiguration {
+	b.LastCheckTime = &value
+	return b
+}
+
+// WithLastUpdateTime sets the LastUpdateTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUpdateTime field is set to the value of the last call.
+func (b *ModelUpdateStatusApplyConfiguration) WithLastUpdateTime(value v1.Time) *ModelUpdateStatusApplyConfiguration {
+	b.LastUpdateTime = &value
+	return b
+}
+
+// WithPreviousDigest sets the PreviousDigest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PreviousDigest field is set to the value of the last call.
+func (b *ModelUpdateStatusApplyConfiguration) WithPreviousDigest(value string) *ModelUpdateStatusApplyConfiguration {
+	b.PreviousDigest = &value
+	return b
+}
+
+// WithLastError sets the LastError field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastError field is set to the value of the last call.
+func (b *ModelUpdateStatusApplyConfiguration) WithLastError(value string) *ModelUpdateStatusApplyConfiguration {
+	b.LastError = &value
+	return b
+}
@@ -0,0 +1,43 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// NetworkPolicyApplyConfiguration represents a declarative configuration of the NetworkPolicy type for use
+// with apply.
+//
+// NetworkPolicy restricts access to the Ollama API, which has no authentication, so that anything in the cluster
+// can otherwise call e.g. /api/pull or /api/delete. Pods of the operator are always allowed.
+type NetworkPolicyApplyConfiguration struct {
+	// From lists peers allowed to reach the Ollama API. With spec.expose the pods of the ingress controller or the Gateway
+	// must be listed, otherwise the Exposed condition is True while the exposed traffic is dropped.
+	From []NetworkPolicyPeerApplyConfiguration `json:"from,omitempty"`
+	// Patches are applied to the generated NetworkPolicy.
+	Patches *PatchesApplyConfiguration `json:"patches,omitempty"`
+}
+
+// NetworkPolicyApplyConfiguration constructs a declarative configuration of the NetworkPolicy type for use with
+// apply.
+func NetworkPolicy() *NetworkPolicyApplyConfiguration {
+	return &NetworkPolicyApplyConfiguration{}
+}
+
+// WithFrom adds the given value to the From field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the From field.
+func (b *NetworkPolicyApplyConfiguration) WithFrom(values ...*NetworkPolicyPeerApplyConfiguration) *NetworkPolicyApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFrom")
+		}
+		b.From = append(b.From, *values[i])
+	}
+	return b
+}
+
+// WithPatches sets the Patches field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Patches field is set to the value of the last call.
+func (b *NetworkPolicyApplyConfiguration) WithPatches(value *PatchesApplyConfiguration) *NetworkPolicyApplyConfiguration {
+	b.Patches = value
+	return b
+}
@@ -0,0 +1,39 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// NetworkPolicyPeerApplyConfiguration represents a declarative configuration of the NetworkPolicyPeer type for use
+// with apply.
+//
+// NetworkPolicyPeer selects pods allowed to reach the Ollama API. Pods from the Model's namespace are selected
+// if NamespaceSelector is not set, all pods of the selected namespaces if PodSelector is not set.
+type NetworkPolicyPeerApplyConfiguration struct {
+	NamespaceSelector *v1.LabelSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
+	PodSelector       *v1.LabelSelectorApplyConfiguration `json:"podSelector,omitempty"`
+}
+
+// NetworkPolicyPeerApplyConfiguration constructs a declarative configuration of the NetworkPolicyPeer type for use with
+// apply.
+func NetworkPolicyPeer() *NetworkPolicyPeerApplyConfiguration {
+	return &NetworkPolicyPeerApplyConfiguration{}
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *NetworkPolicyPeerApplyConfiguration) WithNamespaceSelector(value *v1.LabelSelectorApplyConfiguration) *NetworkPolicyPeerApplyConfiguration {
+	b.NamespaceSelector = value
+	return b
+}
+
+// WithPodSelector sets the PodSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodSelector field is set to the value of the last call.
+func (b *NetworkPolicyPeerApplyConfiguration) WithPodSelector(value *v1.LabelSelectorApplyConfiguration) *NetworkPolicyPeerApplyConfiguration {
+	b.PodSelector = value
+	return b
+}
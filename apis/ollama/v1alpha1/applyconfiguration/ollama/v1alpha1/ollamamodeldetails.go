@@ -11,6 +11,20 @@ type OllamaModelDetailsApplyConfiguration struct {
 	Format            *string  `json:"format,omitempty"`
 	Family            *string  `json:"family,omitempty"`
 	Families          []string `json:"families,omitempty"`
+	// Capabilities of the model, e.g. completion, vision, tools, embedding or thinking.
+	Capabilities []string `json:"capabilities,omitempty"`
+	// Architecture of the model, e.g. llama.
+	Architecture *string `json:"architecture,omitempty"`
+	// ContextLength is the maximum context length the model was trained with.
+	ContextLength *int64 `json:"contextLength,omitempty"`
+	// License of the model.
+	License *string `json:"license,omitempty"`
+	// Template is the default prompt template of the model.
+	Template *string `json:"template,omitempty"`
+	// System is the default system prompt of the model.
+	System *string `json:"system,omitempty"`
+	// Parameters are the default parameters of the model, in the Modelfile format.
+	Parameters *string `json:"parameters,omitempty"`
 }
 
 // OllamaModelDetailsApplyConfiguration constructs a declarative configuration of the OllamaModelDetails type for use with
@@ -68,3 +82,61 @@ func (b *OllamaModelDetailsApplyConfiguration) WithFamilies(values ...string) *O
 	}
 	return b
 }
+
+// WithCapabilities adds the given value to the Capabilities field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Capabilities field.
+func (b *OllamaModelDetailsApplyConfiguration) WithCapabilities(values ...string) *OllamaModelDetailsApplyConfiguration {
+	for i := range values {
+		b.Capabilities = append(b.Capabilities, values[i])
+	}
+	return b
+}
+
+// WithArchitecture sets the Architecture field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Architecture field is set to the value of the last call.
+func (b *OllamaModelDetailsApplyConfiguration) WithArchitecture(value string) *OllamaModelDetailsApplyConfiguration {
+	b.Architecture = &value
+	return b
+}
+
+// WithContextLength sets the ContextLength field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ContextLength field is set to the value of the last call.
+func (b *OllamaModelDetailsApplyConfiguration) WithContextLength(value int64) *OllamaModelDetailsApplyConfiguration {
+	b.ContextLength = &value
+	return b
+}
+
+// WithLicense sets the License field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the License field is set to the value of the last call.
+func (b *OllamaModelDetailsApplyConfiguration) WithLicense(value string) *OllamaModelDetailsApplyConfiguration {
+	b.License = &value
+	return b
+}
+
+// WithTemplate sets the Template field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Template field is set to the value of the last call.
+func (b *OllamaModelDetailsApplyConfiguration) WithTemplate(value string) *OllamaModelDetailsApplyConfiguration {
+	b.Template = &value
+	return b
+}
+
+// WithSystem sets the System field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the System field is set to the value of the last call.
+func (b *OllamaModelDetailsApplyConfiguration) WithSystem(value string) *OllamaModelDetailsApplyConfiguration {
+	b.System = &value
+	return b
+}
+
+// WithParameters sets the Parameters field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Parameters field is set to the value of the last call.
+func (b *OllamaModelDetailsApplyConfiguration) WithParameters(value string) *OllamaModelDetailsApplyConfiguration {
+	b.Parameters = &value
+	return b
+}
@@ -0,0 +1,100 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// OllamaServerSpecApplyConfiguration represents a declarative configuration of the OllamaServerSpec type for use
+// with apply.
+//
+// OllamaServerSpec defines the desired state of OllamaServer. Models reference it with spec.serverRef to share its
+// StatefulSet instead of creating their own. It isn't deleted until all of those Models are deleted.
+type OllamaServerSpecApplyConfiguration struct {
+	// https://hub.docker.com/r/ollama/ollama/tags
+	OllamaImage *string `json:"ollamaImage,omitempty"`
+	// Replicas is the number of Ollama pods. Every replica pulls the models into its own volume.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Storage configures the volume holding pulled models of all Models hosted on the server.
+	Storage *StorageApplyConfiguration `json:"storage,omitempty"`
+	// RegistryCredentialsSecretRef references a Secret with the key pair the Ollama server uses to authenticate to the registry,
+	// see Registry.CredentialsSecretRef.
+	RegistryCredentialsSecretRef *v1.LocalObjectReference `json:"registryCredentialsSecretRef,omitempty"`
+	// Server configures the Ollama server.
+	Server *ServerApplyConfiguration `json:"server,omitempty"`
+	// NetworkPolicy generates a NetworkPolicy allowing ingress traffic to the Ollama pods only from the operator and the listed peers.
+	NetworkPolicy      *NetworkPolicyApplyConfiguration `json:"networkPolicy,omitempty"`
+	StatefulSetPatches *PatchesApplyConfiguration       `json:"statefulSetPatches,omitempty"`
+	ServicePatches     *PatchesApplyConfiguration       `json:"servicePatches,omitempty"`
+}
+
+// OllamaServerSpecApplyConfiguration constructs a declarative configuration of the OllamaServerSpec type for use with
+// apply.
+func OllamaServerSpec() *OllamaServerSpecApplyConfiguration {
+	return &OllamaServerSpecApplyConfiguration{}
+}
+
+// WithOllamaImage sets the OllamaImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OllamaImage field is set to the value of the last call.
+func (b *OllamaServerSpecApplyConfiguration) WithOllamaImage(value string) *OllamaServerSpecApplyConfiguration {
+	b.OllamaImage = &value
+	return b
+}
+
+// WithReplicas sets the Replicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replicas field is set to the value of the last call.
+func (b *OllamaServerSpecApplyConfiguration) WithReplicas(value int32) *OllamaServerSpecApplyConfiguration {
+	b.Replicas = &value
+	return b
+}
+
+// WithStorage sets the Storage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Storage field is set to the value of the last call.
+func (b *OllamaServerSpecApplyConfiguration) WithStorage(value *StorageApplyConfiguration) *OllamaServerSpecApplyConfiguration {
+	b.Storage = value
+	return b
+}
+
+// WithRegistryCredentialsSecretRef sets the RegistryCredentialsSecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RegistryCredentialsSecretRef field is set to the value of the last call.
+func (b *OllamaServerSpecApplyConfiguration) WithRegistryCredentialsSecretRef(value v1.LocalObjectReference) *OllamaServerSpecApplyConfiguration {
+	b.RegistryCredentialsSecretRef = &value
+	return b
+}
+
+// WithServer sets the Server field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Server field is set to the value of the last call.
+func (b *OllamaServerSpecApplyConfiguration) WithServer(value *ServerApplyConfiguration) *OllamaServerSpecApplyConfiguration {
+	b.Server = value
+	return b
+}
+
+// WithNetworkPolicy sets the NetworkPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NetworkPolicy field is set to the value of the last call.
+func (b *OllamaServerSpecApplyConfiguration) WithNetworkPolicy(value *NetworkPolicyApplyConfiguration) *OllamaServerSpecApplyConfiguration {
+	b.NetworkPolicy = value
+	return b
+}
+
+// WithStatefulSetPatches sets the StatefulSetPatches field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StatefulSetPatches field is set to the value of the last call.
+func (b *OllamaServerSpecApplyConfiguration) WithStatefulSetPatches(value *PatchesApplyConfiguration) *OllamaServerSpecApplyConfiguration {
+	b.StatefulSetPatches = value
+	return b
+}
+
+// WithServicePatches sets the ServicePatches field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServicePatches field is set to the value of the last call.
+func (b *OllamaServerSpecApplyConfiguration) WithServicePatches(value *PatchesApplyConfiguration) *OllamaServerSpecApplyConfiguration {
+	b.ServicePatches = value
+	return b
+}
@@ -0,0 +1,78 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+)
+
+// OllamaServerStatusApplyConfiguration represents a declarative configuration of the OllamaServerStatus type for use
+// with apply.
+//
+// OllamaServerStatus defines the observed state of OllamaServer
+type OllamaServerStatusApplyConfiguration struct {
+	ConditionedStatusApplyConfiguration `json:",inline"`
+	// ObservedGeneration is the latest metadata.generation
+	// which resulted in either a ready state, or stalled due to error
+	// it can not recover from without human intervention.
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+	// OllamaImage is the Ollama image the server runs.
+	OllamaImage *string `json:"ollamaImage,omitempty"`
+	// ReadyReplicas is the number of ready Ollama pods.
+	ReadyReplicas *int32 `json:"readyReplicas,omitempty"`
+	// Models lists Models hosted on the server.
+	Models []HostedModelApplyConfiguration `json:"models,omitempty"`
+}
+
+// OllamaServerStatusApplyConfiguration constructs a declarative configuration of the OllamaServerStatus type for use with
+// apply.
+func OllamaServerStatus() *OllamaServerStatusApplyConfiguration {
+	return &OllamaServerStatusApplyConfiguration{}
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *OllamaServerStatusApplyConfiguration) WithConditions(values ...v2.Condition) *OllamaServerStatusApplyConfiguration {
+	for i := range values {
+		b.ConditionedStatusApplyConfiguration.Conditions = append(b.ConditionedStatusApplyConfiguration.Conditions, values[i])
+	}
+	return b
+}
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *OllamaServerStatusApplyConfiguration) WithObservedGeneration(value int64) *OllamaServerStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithOllamaImage sets the OllamaImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OllamaImage field is set to the value of the last call.
+func (b *OllamaServerStatusApplyConfiguration) WithOllamaImage(value string) *OllamaServerStatusApplyConfiguration {
+	b.OllamaImage = &value
+	return b
+}
+
+// WithReadyReplicas sets the ReadyReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadyReplicas field is set to the value of the last call.
+func (b *OllamaServerStatusApplyConfiguration) WithReadyReplicas(value int32) *OllamaServerStatusApplyConfiguration {
+	b.ReadyReplicas = &value
+	return b
+}
+
+// WithModels adds the given value to the Models field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Models field.
+func (b *OllamaServerStatusApplyConfiguration) WithModels(values ...*HostedModelApplyConfiguration) *OllamaServerStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithModels")
+		}
+		b.Models = append(b.Models, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,33 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// PersistentVolumeClaimFileApplyConfiguration represents a declarative configuration of the PersistentVolumeClaimFile type for use
+// with apply.
+type PersistentVolumeClaimFileApplyConfiguration struct {
+	ClaimName *string `json:"claimName,omitempty"`
+	// Path of the file relative to the root of the volume, e.g. models/llama-finetuned.gguf.
+	Path *string `json:"path,omitempty"`
+}
+
+// PersistentVolumeClaimFileApplyConfiguration constructs a declarative configuration of the PersistentVolumeClaimFile type for use with
+// apply.
+func PersistentVolumeClaimFile() *PersistentVolumeClaimFileApplyConfiguration {
+	return &PersistentVolumeClaimFileApplyConfiguration{}
+}
+
+// WithClaimName sets the ClaimName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClaimName field is set to the value of the last call.
+func (b *PersistentVolumeClaimFileApplyConfiguration) WithClaimName(value string) *PersistentVolumeClaimFileApplyConfiguration {
+	b.ClaimName = &value
+	return b
+}
+
+// WithPath sets the Path field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Path field is set to the value of the last call.
+func (b *PersistentVolumeClaimFileApplyConfiguration) WithPath(value string) *PersistentVolumeClaimFileApplyConfiguration {
+	b.Path = &value
+	return b
+}
@@ -0,0 +1,40 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// RegistryApplyConfiguration represents a declarative configuration of the Registry type for use
+// with apply.
+type RegistryApplyConfiguration struct {
+	// Insecure pulls over plain HTTP, e.g. from internal registry mirrors. It doesn't skip TLS verification, registries
+	// served over HTTPS need a certificate trusted by the Ollama image.
+	Insecure *bool `json:"insecure,omitempty"`
+	// CredentialsSecretRef references a Secret in the Model's namespace with the key pair the Ollama server uses
+	// to authenticate to the registry, stored under id_ed25519 and id_ed25519.pub keys. The keys are mounted into ~/.ollama.
+	CredentialsSecretRef *v1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// RegistryApplyConfiguration constructs a declarative configuration of the Registry type for use with
+// apply.
+func Registry() *RegistryApplyConfiguration {
+	return &RegistryApplyConfiguration{}
+}
+
+// WithInsecure sets the Insecure field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Insecure field is set to the value of the last call.
+func (b *RegistryApplyConfiguration) WithInsecure(value bool) *RegistryApplyConfiguration {
+	b.Insecure = &value
+	return b
+}
+
+// WithCredentialsSecretRef sets the CredentialsSecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CredentialsSecretRef field is set to the value of the last call.
+func (b *RegistryApplyConfiguration) WithCredentialsSecretRef(value v1.LocalObjectReference) *RegistryApplyConfiguration {
+	b.CredentialsSecretRef = &value
+	return b
+}
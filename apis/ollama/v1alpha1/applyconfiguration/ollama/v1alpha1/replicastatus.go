@@ -0,0 +1,71 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+// ReplicaStatusApplyConfiguration represents a declarative configuration of the ReplicaStatus type for use
+// with apply.
+type ReplicaStatusApplyConfiguration struct {
+	// Pod is the name of the Ollama pod.
+	Pod *string `json:"pod,omitempty"`
+	// Ready is true once the pod is ready and has all models pulled.
+	Ready *bool `json:"ready,omitempty"`
+	// Models lists desired models already present on the pod.
+	Models []string `json:"models,omitempty"`
+	// CreatedModels maps names of models created on the pod to the hash of the Modelfile they were created from.
+	CreatedModels map[string]string `json:"createdModels,omitempty"`
+	Message       *string           `json:"message,omitempty"`
+}
+
+// ReplicaStatusApplyConfiguration constructs a declarative configuration of the ReplicaStatus type for use with
+// apply.
+func ReplicaStatus() *ReplicaStatusApplyConfiguration {
+	return &ReplicaStatusApplyConfiguration{}
+}
+
+// WithPod sets the Pod field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Pod field is set to the value of the last call.
+func (b *ReplicaStatusApplyConfiguration) WithPod(value string) *ReplicaStatusApplyConfiguration {
+	b.Pod = &value
+	return b
+}
+
+// WithReady sets the Ready field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ready field is set to the value of the last call.
+func (b *ReplicaStatusApplyConfiguration) WithReady(value bool) *ReplicaStatusApplyConfiguration {
+	b.Ready = &value
+	return b
+}
+
+// WithModels adds the given value to the Models field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Models field.
+func (b *ReplicaStatusApplyConfiguration) WithModels(values ...string) *ReplicaStatusApplyConfiguration {
+	for i := range values {
+		b.Models = append(b.Models, values[i])
+	}
+	return b
+}
+
+// WithCreatedModels puts the entries into the CreatedModels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the CreatedModels field,
+// overwriting an existing map entries in CreatedModels field with the same key.
+func (b *ReplicaStatusApplyConfiguration) WithCreatedModels(entries map[string]string) *ReplicaStatusApplyConfiguration {
+	if b.CreatedModels == nil && len(entries) > 0 {
+		b.CreatedModels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.CreatedModels[k] = v
+	}
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ReplicaStatusApplyConfiguration) WithMessage(value string) *ReplicaStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
@@ -0,0 +1,78 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// StorageApplyConfiguration represents a declarative configuration of the Storage type for use
+// with apply.
+//
+// Storage configures where Ollama keeps the models. By default every replica gets its own 20Gi ReadWriteOnce PVC.
+type StorageApplyConfiguration struct {
+	// Size of the PVC, defaults to 20Gi. Existing PVCs are expanded in place when it grows, shrinking is not supported.
+	Size *resource.Quantity `json:"size,omitempty"`
+	// StorageClassName of the PVC, defaults to the cluster default storage class. It can't be changed, like AccessModes
+	// and EmptyDir, volume claim templates of the StatefulSet are immutable.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// AccessModes of the PVC, defaults to ReadWriteOnce.
+	AccessModes []v1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+	// EmptyDir keeps the models in an emptyDir volume instead of a PVC, useful for throwaway models.
+	// Models are pulled again every time the pod is recreated.
+	EmptyDir *v1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+	// RetentionPolicy decides whether the PVCs are deleted together with the Model, defaults to Retain. It's set as
+	// whenDeleted of the StatefulSet's persistentVolumeClaimRetentionPolicy, the model controller deletes the PVCs
+	// in a finalizer too, in case the cluster doesn't support it.
+	RetentionPolicy *ollamav1alpha1.PVCRetentionPolicy `json:"retentionPolicy,omitempty"`
+}
+
+// StorageApplyConfiguration constructs a declarative configuration of the Storage type for use with
+// apply.
+func Storage() *StorageApplyConfiguration {
+	return &StorageApplyConfiguration{}
+}
+
+// WithSize sets the Size field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Size field is set to the value of the last call.
+func (b *StorageApplyConfiguration) WithSize(value resource.Quantity) *StorageApplyConfiguration {
+	b.Size = &value
+	return b
+}
+
+// WithStorageClassName sets the StorageClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StorageClassName field is set to the value of the last call.
+func (b *StorageApplyConfiguration) WithStorageClassName(value string) *StorageApplyConfiguration {
+	b.StorageClassName = &value
+	return b
+}
+
+// WithAccessModes adds the given value to the AccessModes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AccessModes field.
+func (b *StorageApplyConfiguration) WithAccessModes(values ...v1.PersistentVolumeAccessMode) *StorageApplyConfiguration {
+	for i := range values {
+		b.AccessModes = append(b.AccessModes, values[i])
+	}
+	return b
+}
+
+// WithEmptyDir sets the EmptyDir field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EmptyDir field is set to the value of the last call.
+func (b *StorageApplyConfiguration) WithEmptyDir(value v1.EmptyDirVolumeSource) *StorageApplyConfiguration {
+	b.EmptyDir = &value
+	return b
+}
+
+// WithRetentionPolicy sets the RetentionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetentionPolicy field is set to the value of the last call.
+func (b *StorageApplyConfiguration) WithRetentionPolicy(value ollamav1alpha1.PVCRetentionPolicy) *StorageApplyConfiguration {
+	b.RetentionPolicy = &value
+	return b
+}
@@ -0,0 +1,41 @@
+// Code generated by controller-gen-v0.21. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpdatePolicyApplyConfiguration represents a declarative configuration of the UpdatePolicy type for use
+// with apply.
+//
+// UpdatePolicy configures re-pulling of models. Models are re-pulled in the background, Ollama keeps serving the old version
+// until the new one is fully downloaded and verified, and the Model stays Ready throughout.
+type UpdatePolicyApplyConfiguration struct {
+	Type *ollamav1alpha1.UpdatePolicyType `json:"type,omitempty"`
+	// Interval between re-pulls, e.g. 24h. The first re-pull is due an interval after the model was pulled.
+	Interval *v1.Duration `json:"interval,omitempty"`
+}
+
+// UpdatePolicyApplyConfiguration constructs a declarative configuration of the UpdatePolicy type for use with
+// apply.
+func UpdatePolicy() *UpdatePolicyApplyConfiguration {
+	return &UpdatePolicyApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *UpdatePolicyApplyConfiguration) WithType(value ollamav1alpha1.UpdatePolicyType) *UpdatePolicyApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithInterval sets the Interval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Interval field is set to the value of the last call.
+func (b *UpdatePolicyApplyConfiguration) WithInterval(value v1.Duration) *UpdatePolicyApplyConfiguration {
+	b.Interval = &value
+	return b
+}
@@ -16,12 +16,26 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=ollama.aerf.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("APIKey"):
+		return &ollamav1alpha1.APIKeyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Auth"):
+		return &ollamav1alpha1.AuthApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AuthStatus"):
+		return &ollamav1alpha1.AuthStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ConditionedStatus"):
 		return &ollamav1alpha1.ConditionedStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ConfigMapKeySelector"):
 		return &ollamav1alpha1.ConfigMapKeySelectorApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ConfigMapReference"):
 		return &ollamav1alpha1.ConfigMapReferenceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Expose"):
+		return &ollamav1alpha1.ExposeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ExposeStatus"):
+		return &ollamav1alpha1.ExposeStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GatewayReference"):
+		return &ollamav1alpha1.GatewayReferenceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("HostedModel"):
+		return &ollamav1alpha1.HostedModelApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ImageData"):
 		return &ollamav1alpha1.ImageDataApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ImageSource"):
@@ -34,16 +48,54 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &ollamav1alpha1.MergePatchApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Model"):
 		return &ollamav1alpha1.ModelApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ModelAccessGrant"):
+		return &ollamav1alpha1.ModelAccessGrantApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ModelAccessGrantFrom"):
+		return &ollamav1alpha1.ModelAccessGrantFromApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ModelAccessGrantSpec"):
+		return &ollamav1alpha1.ModelAccessGrantSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ModelAccessGrantTo"):
+		return &ollamav1alpha1.ModelAccessGrantToApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ModelCreate"):
+		return &ollamav1alpha1.ModelCreateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ModelEntry"):
+		return &ollamav1alpha1.ModelEntryApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ModelEntryStatus"):
+		return &ollamav1alpha1.ModelEntryStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ModelFile"):
+		return &ollamav1alpha1.ModelFileApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ModelLoadStatus"):
+		return &ollamav1alpha1.ModelLoadStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ModelMessage"):
+		return &ollamav1alpha1.ModelMessageApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ModelParameter"):
+		return &ollamav1alpha1.ModelParameterApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ModelRef"):
 		return &ollamav1alpha1.ModelRefApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ModelSource"):
+		return &ollamav1alpha1.ModelSourceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ModelSpec"):
 		return &ollamav1alpha1.ModelSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ModelStatus"):
 		return &ollamav1alpha1.ModelStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ModelUpdateStatus"):
+		return &ollamav1alpha1.ModelUpdateStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NetworkPolicy"):
+		return &ollamav1alpha1.NetworkPolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NetworkPolicyPeer"):
+		return &ollamav1alpha1.NetworkPolicyPeerApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("OllamaModelDetails"):
 		return &ollamav1alpha1.OllamaModelDetailsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("OllamaServer"):
+		return &ollamav1alpha1.OllamaServerApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("OllamaServerSpec"):
+		return &ollamav1alpha1.OllamaServerSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("OllamaServerStatus"):
+		return &ollamav1alpha1.OllamaServerStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Patches"):
 		return &ollamav1alpha1.PatchesApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PersistentVolumeClaimFile"):
+		return &ollamav1alpha1.PersistentVolumeClaimFileApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Prompt"):
 		return &ollamav1alpha1.PromptApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PromptResponseMeta"):
@@ -54,6 +106,18 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &ollamav1alpha1.PromptSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PromptStatus"):
 		return &ollamav1alpha1.PromptStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PullProgress"):
+		return &ollamav1alpha1.PullProgressApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Registry"):
+		return &ollamav1alpha1.RegistryApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ReplicaStatus"):
+		return &ollamav1alpha1.ReplicaStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Server"):
+		return &ollamav1alpha1.ServerApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Storage"):
+		return &ollamav1alpha1.StorageApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UpdatePolicy"):
+		return &ollamav1alpha1.UpdatePolicyApplyConfiguration{}
 
 	}
 	return nil
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"slices"
 	"sort"
 
 	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
@@ -80,6 +81,13 @@ func (s *ConditionedStatus) SetConditions(c ...xpv2.Condition) {
 	}
 }
 
+// RemoveConditions removes the conditions of the supplied types, if they exist.
+func (s *ConditionedStatus) RemoveConditions(ct ...xpv2.ConditionType) {
+	s.Conditions = slices.DeleteFunc(s.Conditions, func(c xpv2.Condition) bool {
+		return slices.Contains(ct, c.Type)
+	})
+}
+
 // Equal returns true if the status is identical to the supplied status,
 // ignoring the LastTransitionTimes and order of statuses.
 func (s *ConditionedStatus) Equal(other *ConditionedStatus) bool {
@@ -1,11 +1,15 @@
 package v1alpha1
 
 const (
-	ModelKind  = "Model"
-	PromptKind = "Prompt"
+	ModelKind            = "Model"
+	PromptKind           = "Prompt"
+	OllamaServerKind     = "OllamaServer"
+	ModelAccessGrantKind = "ModelAccessGrant"
 )
 
 var (
-	ModelGroupVersionKind  = SchemeGroupVersion.WithKind(ModelKind)
-	PromptGroupVersionKind = SchemeGroupVersion.WithKind(PromptKind)
+	ModelGroupVersionKind            = SchemeGroupVersion.WithKind(ModelKind)
+	PromptGroupVersionKind           = SchemeGroupVersion.WithKind(PromptKind)
+	OllamaServerGroupVersionKind     = SchemeGroupVersion.WithKind(OllamaServerKind)
+	ModelAccessGrantGroupVersionKind = SchemeGroupVersion.WithKind(ModelAccessGrantKind)
 )
@@ -17,21 +17,493 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"slices"
+
 	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // ModelSpec defines the desired state of Model
+// +kubebuilder:validation:XValidation:rule="has(self.model) || (has(self.models) && size(self.models) > 0)",message="at least one of model or models must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.create) || has(self.model)",message="create requires model to be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.digest) || has(self.model)",message="digest requires model to be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.digest) || !has(self.create)",message="digest can't be combined with create"
+// +kubebuilder:validation:XValidation:rule="!has(self.source) || !has(self.source.snapshot) || !has(self.storage) || !has(self.storage.emptyDir)",message="source.snapshot can't be combined with storage.emptyDir"
+// +kubebuilder:validation:XValidation:rule="!has(self.source) || !has(self.source.imageVolume) || (!has(self.create) && !has(self.updatePolicy) && (!has(self.models) || self.models.all(m, !has(m.create))))",message="source.imageVolume can't be combined with create or updatePolicy, models can't be created or pulled into the read-only image"
+// +kubebuilder:validation:XValidation:rule="(has(self.source) && has(self.source.snapshot)) == (has(oldSelf.source) && has(oldSelf.source.snapshot)) && (!has(self.source) || !has(self.source.snapshot) || self.source.snapshot == oldSelf.source.snapshot)",message="source.snapshot can't be added, changed or removed, volume claim templates of the StatefulSet are immutable"
+// +kubebuilder:validation:XValidation:rule="(has(self.storage) && has(self.storage.storageClassName)) == (has(oldSelf.storage) && has(oldSelf.storage.storageClassName)) && (!has(self.storage) || !has(self.storage.storageClassName) || self.storage.storageClassName == oldSelf.storage.storageClassName) && (has(self.storage) && has(self.storage.accessModes) ? self.storage.accessModes : ['ReadWriteOnce']) == (has(oldSelf.storage) && has(oldSelf.storage.accessModes) ? oldSelf.storage.accessModes : ['ReadWriteOnce']) && (has(self.storage) && has(self.storage.emptyDir)) == (has(oldSelf.storage) && has(oldSelf.storage.emptyDir))",message="storage.storageClassName, storage.accessModes and storage.emptyDir can't be changed, volume claim templates of the StatefulSet are immutable"
+// +kubebuilder:validation:XValidation:rule="!has(self.updatePolicy) || !has(self.pullMode) || self.pullMode != 'InitContainer'",message="updatePolicy can't be combined with pullMode InitContainer, updates are pulled by the operator"
+// +kubebuilder:validation:XValidation:rule="!has(self.expose) || (has(self.auth) && !has(self.serverRef))",message="expose requires auth and can't be combined with serverRef, the Ollama API has no authentication of its own"
+// +kubebuilder:validation:XValidation:rule="!has(self.serverRef) || (!has(self.ollamaImage) && !has(self.storage) && !has(self.server) && !has(self.statefulSetPatches) && !has(self.servicePatches) && !has(self.networkPolicy) && !has(self.auth) && !has(self.idle) && !has(self.suspend) && !has(self.pullMode) && !has(self.source) && !(has(self.registry) && has(self.registry.credentialsSecretRef)))",message="serverRef can't be combined with ollamaImage, storage, server, registry.credentialsSecretRef, networkPolicy, auth, idle, suspend, pullMode, source or patches, configure them on the OllamaServer"
 type ModelSpec struct {
 	// https://hub.docker.com/r/ollama/ollama/tags
 	OllamaImage string `json:"ollamaImage,omitempty"`
-	// Model like phi3, llama3.1 etc
-	Model              string   `json:"model"`
+	// Model like phi3, llama3.1 etc. Shorthand for a single entry in Models.
+	Model string `json:"model,omitempty"`
+	// Digest pins the expected digest of Model. See ModelEntry.Digest.
+	// +kubebuilder:validation:Pattern=`^(sha256:)?[a-f0-9]{12,64}$`
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// Models served by the same Ollama server. If Model is set as well it's served alongside those.
+	// +listType=map
+	// +listMapKey=name
+	Models []ModelEntry `json:"models,omitempty"`
+	// Create builds Model from a Modelfile with Ollama's create API instead of pulling it from the registry.
+	// +optional
+	Create *ModelCreate `json:"create,omitempty"`
+	// ServerRef references an OllamaServer in the Model's namespace the models are pulled onto, instead of running
+	// a dedicated Ollama StatefulSet for the Model. Replicas are ignored then, the server's ones are used.
+	// +optional
+	ServerRef *corev1.LocalObjectReference `json:"serverRef,omitempty"`
+	// Replicas is the number of Ollama pods. Every replica pulls the models into its own volume.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Storage configures the volume holding pulled models.
+	// +optional
+	Storage *Storage `json:"storage,omitempty"`
+	// Registry configures how models are pulled from the registry. The registry itself is part of the model name,
+	// e.g. registry.example.com/library/phi3:latest, and defaults to registry.ollama.ai.
+	// +optional
+	Registry *Registry `json:"registry,omitempty"`
+	// Source seeds Ollama's models directory, so that models found in it are not pulled from the registry.
+	// +optional
+	Source *ModelSource `json:"source,omitempty"`
+	// PullMode decides how models are pulled, defaults to Operator. With InitContainer the pods pull the models
+	// in an init container running a temporary Ollama server, so the operator doesn't have to reach Ollama for the
+	// pulls and only verifies the models. Created models are always created by the operator.
+	// +optional
+	PullMode PullMode `json:"pullMode,omitempty"`
+	// UpdatePolicy configures re-pulling of models to pick up changes of moving tags like llama3.1.
+	// Created models and models with a pinned digest are never updated. It can't be combined with pullMode InitContainer.
+	// +optional
+	UpdatePolicy *UpdatePolicy `json:"updatePolicy,omitempty"`
+	// Idle scales the StatefulSet to zero once the Model wasn't used for this long, e.g. 24h. The volume is kept, so
+	// the models don't have to be pulled again. Prompts and models loaded in Ollama count as use, a new Prompt referencing
+	// the Model scales it back up. Ollama unloads models after spec.server.keepAlive, 5m by default with Idle set.
+	// +optional
+	Idle *metav1.Duration `json:"idle,omitempty"`
+	// Suspend scales the StatefulSet to zero without deleting the Model, its volume or generated objects.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// WarmUp loads the models into memory on every replica before the Model is reported Ready,
+	// so that the first Prompt doesn't pay the load time.
+	// +optional
+	WarmUp bool `json:"warmUp,omitempty"`
+	// Server configures the Ollama server. Env vars set with statefulSetPatches take precedence over the fields set here,
+	// conflicts are reported in the ServerConfigured condition.
+	// +optional
+	Server *Server `json:"server,omitempty"`
+	// NetworkPolicy generates a NetworkPolicy allowing ingress traffic to the Ollama pods only from the operator and the listed peers.
+	// +optional
+	NetworkPolicy *NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Auth puts a reverse proxy checking API keys in front of Ollama. The Service routes through it, Ollama itself
+	// listens only on localhost then.
+	// +optional
+	Auth *Auth `json:"auth,omitempty"`
+	// Expose routes traffic from outside of the cluster to the Ollama API of the Model with an Ingress or a Gateway API HTTPRoute.
+	// It requires auth, so it can't be used by Models referencing an OllamaServer, which doesn't support it.
+	// +optional
+	Expose *Expose `json:"expose,omitempty"`
+	// CleanupOnDelete adds a finalizer that deletes the pulled models from Ollama before the Model is removed.
+	// Useful when the volume outlives the Model.
+	// +optional
+	CleanupOnDelete    bool     `json:"cleanupOnDelete,omitempty"`
 	StatefulSetPatches *Patches `json:"statefulSetPatches,omitempty"`
 	ServicePatches     *Patches `json:"servicePatches,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.digest) || !has(self.create)",message="digest can't be combined with create"
+type ModelEntry struct {
+	// Name of the model like phi3, llama3.1 etc
+	Name string `json:"name"`
+	// Digest pins the expected digest of the model, as reported by `ollama list`, e.g. sha256:4f2222927938 or its full form.
+	// Tags are mutable, so the digest of the pulled model is verified and a mismatch stalls the Model.
+	// +kubebuilder:validation:Pattern=`^(sha256:)?[a-f0-9]{12,64}$`
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// Create builds the model from a Modelfile with Ollama's create API instead of pulling it from the registry.
+	// +optional
+	Create *ModelCreate `json:"create,omitempty"`
+}
+
+// ModelCreate is a Modelfile, see https://github.com/ollama/ollama/blob/main/docs/modelfile.md.
+// It's either set inline or read from a ConfigMap. The model is re-created whenever it changes.
+// +kubebuilder:validation:XValidation:rule="[has(self.from), has(self.modelfileRef), has(self.files)].exists_one(x, x)",message="exactly one of from, modelfileRef or files must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.modelfileRef) || (!has(self.system) && !has(self.template) && !has(self.parameters) && !has(self.messages) && !has(self.license) && !has(self.adapters))",message="modelfileRef can't be combined with other fields"
+type ModelCreate struct {
+	// From is the base model like llama3.1:8b, it's pulled if it's missing.
+	// +optional
+	From string `json:"from,omitempty"`
+	// Files are GGUF or safetensors weights the model is created from instead of a base model, e.g. for air-gapped clusters.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Files []ModelFile `json:"files,omitempty"`
+	// Adapters are LoRA adapters applied to the model.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Adapters []ModelFile `json:"adapters,omitempty"`
+	// System message of the model.
+	// +optional
+	System string `json:"system,omitempty"`
+	// Template is the full prompt template of the model.
+	// +optional
+	Template string `json:"template,omitempty"`
+	// Parameters like temperature or num_ctx. A parameter can be repeated, e.g. stop.
+	// +listType=atomic
+	// +optional
+	Parameters []ModelParameter `json:"parameters,omitempty"`
+	// Messages build up the conversation history the model starts with.
+	// +listType=atomic
+	// +optional
+	Messages []ModelMessage `json:"messages,omitempty"`
+	// License of the model.
+	// +listType=atomic
+	// +optional
+	License []string `json:"license,omitempty"`
+	// ModelfileRef references a key of a ConfigMap in the Model's namespace holding a Modelfile.
+	// Changes of the ConfigMap are picked up within a minute.
+	// +optional
+	ModelfileRef *corev1.ConfigMapKeySelector `json:"modelfileRef,omitempty"`
+}
+
+// ModelFile is a file uploaded to Ollama with the blob API before the model is created.
+// Upload progress is reported in status.pull like the one of pulls.
+// +kubebuilder:validation:XValidation:rule="[has(self.configMapKeyRef), has(self.secretKeyRef), has(self.persistentVolumeClaim)].exists_one(x, x)",message="exactly one of configMapKeyRef, secretKeyRef or persistentVolumeClaim must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.persistentVolumeClaim) || has(self.digest)",message="digest is required for files from a persistentVolumeClaim"
+type ModelFile struct {
+	// Name of the file in the model, e.g. model.gguf or adapter.safetensors.
+	Name string `json:"name"`
+	// Digest is the expected digest of the file like sha256:4f1c..., the model is not created if the file doesn't match it.
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// ConfigMapKeyRef selects a key of a ConfigMap in the Model's namespace. binaryData takes precedence over data.
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// SecretKeyRef selects a key of a Secret in the Model's namespace.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+	// PersistentVolumeClaim selects a file on a PVC in the Model's namespace. The PVC is mounted read-only into a blob-server
+	// sidecar of every Ollama pod, which serves the file to the operator, so its access modes must allow that, e.g. ReadOnlyMany.
+	// The sidecar requires a token generated by the operator in the <model name>-blob-server Secret.
+	// +optional
+	PersistentVolumeClaim *PersistentVolumeClaimFile `json:"persistentVolumeClaim,omitempty"`
+}
+
+type PersistentVolumeClaimFile struct {
+	ClaimName string `json:"claimName"`
+	// Path of the file relative to the root of the volume, e.g. models/llama-finetuned.gguf.
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('/') && !self.contains('..')",message="path must be relative and can't contain '..'"
+	Path string `json:"path"`
+}
+
+type ModelParameter struct {
+	// Name of the parameter, e.g. temperature.
+	Name string `json:"name"`
+	// Value of the parameter, e.g. "0.7".
+	Value string `json:"value"`
+}
+
+type ModelMessage struct {
+	// +kubebuilder:validation:Enum=system;user;assistant
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type Registry struct {
+	// Insecure pulls over plain HTTP, e.g. from internal registry mirrors. It doesn't skip TLS verification, registries
+	// served over HTTPS need a certificate trusted by the Ollama image.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+	// CredentialsSecretRef references a Secret in the Model's namespace with the key pair the Ollama server uses
+	// to authenticate to the registry, stored under id_ed25519 and id_ed25519.pub keys. The keys are mounted into ~/.ollama.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// NetworkPolicy restricts access to the Ollama API, which has no authentication, so that anything in the cluster
+// can otherwise call e.g. /api/pull or /api/delete. Pods of the operator are always allowed.
+type NetworkPolicy struct {
+	// From lists peers allowed to reach the Ollama API. With spec.expose the pods of the ingress controller or the Gateway
+	// must be listed, otherwise the Exposed condition is True while the exposed traffic is dropped.
+	// +listType=atomic
+	// +optional
+	From []NetworkPolicyPeer `json:"from,omitempty"`
+	// Patches are applied to the generated NetworkPolicy.
+	// +optional
+	Patches *Patches `json:"patches,omitempty"`
+}
+
+// NetworkPolicyPeer selects pods allowed to reach the Ollama API. Pods from the Model's namespace are selected
+// if NamespaceSelector is not set, all pods of the selected namespaces if PodSelector is not set.
+// +kubebuilder:validation:XValidation:rule="has(self.namespaceSelector) || has(self.podSelector)",message="at least one of namespaceSelector or podSelector must be set"
+type NetworkPolicyPeer struct {
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// Auth configures API keys accepted by the auth proxy sidecar. Keys are generated into the <model>-api-keys Secret
+// in the Model's namespace, one data key per APIKey name, and are sent as "Authorization: Bearer <key>".
+// The Secret also holds the operator's key of the Model under ollama-operator, allowed to call every endpoint of this Model only.
+type Auth struct {
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	Keys []APIKey `json:"keys"`
+}
+
+// +kubebuilder:validation:XValidation:rule="self.name != 'config.json' && self.name != 'ollama-operator'",message="config.json and ollama-operator are reserved for the auth proxy configuration and the operator's key"
+type APIKey struct {
+	// Name of the key in the generated Secret.
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+	// Endpoints the key may call, e.g. /api/generate and /api/chat. A trailing * matches any suffix, e.g. /v1/*.
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	Endpoints []string `json:"endpoints"`
+}
+
+// +kubebuilder:validation:Enum=Ingress;HTTPRoute
+type ExposeType string
+
+const (
+	ExposeTypeIngress   ExposeType = "Ingress"
+	ExposeTypeHTTPRoute ExposeType = "HTTPRoute"
+)
+
+// Expose configures the Ingress or HTTPRoute generated for the Model. It's named after the Model and routes to its Service,
+// or the one of the OllamaServer the Model references. Requests are forwarded with the path unchanged.
+// +kubebuilder:validation:XValidation:rule="self.type == 'HTTPRoute' ? has(self.gatewayRef) : !has(self.gatewayRef)",message="gatewayRef is required for HTTPRoute and can't be set for Ingress"
+// +kubebuilder:validation:XValidation:rule="self.type == 'Ingress' || (!has(self.className) && !has(self.tlsSecretRef))",message="className and tlsSecretRef are only supported for Ingress, configure them on the Gateway for HTTPRoute"
+type Expose struct {
+	// Type of the generated object.
+	// +kubebuilder:default=Ingress
+	// +optional
+	Type ExposeType `json:"type,omitempty"`
+	// Host the Ollama API is served on, e.g. ollama.example.com. Requests for all hosts are routed when empty.
+	// +optional
+	Host string `json:"host,omitempty"`
+	// Path prefix routed to the Ollama API.
+	// +kubebuilder:default="/"
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path string `json:"path,omitempty"`
+	// ClassName is the ingressClassName of the Ingress.
+	// +optional
+	ClassName *string `json:"className,omitempty"`
+	// TLSSecretRef references a Secret in the Model's namespace with the TLS certificate of Host, the Ingress terminates TLS with it.
+	// +optional
+	TLSSecretRef *corev1.LocalObjectReference `json:"tlsSecretRef,omitempty"`
+	// GatewayRef references the Gateway the HTTPRoute is attached to.
+	// +optional
+	GatewayRef *GatewayReference `json:"gatewayRef,omitempty"`
+	// Patches are applied to the generated Ingress or HTTPRoute.
+	// +optional
+	Patches *Patches `json:"patches,omitempty"`
+}
+
+type GatewayReference struct {
+	// Name of the Gateway.
+	Name string `json:"name"`
+	// Namespace of the Gateway, defaults to the Model's namespace. The Gateway has to allow routes from it.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// SectionName selects a listener of the Gateway, all listeners are used when empty.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Never;OnReconcile;Interval
+type UpdatePolicyType string
+
+const (
+	// UpdatePolicyNever never re-pulls models once they're present.
+	UpdatePolicyNever UpdatePolicyType = "Never"
+	// UpdatePolicyOnReconcile re-pulls models whenever the Model is reconciled, e.g. after a spec change, but at most once a minute.
+	UpdatePolicyOnReconcile UpdatePolicyType = "OnReconcile"
+	// UpdatePolicyInterval re-pulls models periodically, a fixed interval after the previous check. Schedules like cron
+	// expressions are not supported, re-pulls can't be pinned to a time of day.
+	UpdatePolicyInterval UpdatePolicyType = "Interval"
+)
+
+// UpdatePolicy configures re-pulling of models. Models are re-pulled in the background, Ollama keeps serving the old version
+// until the new one is fully downloaded and verified, and the Model stays Ready throughout.
+// +kubebuilder:validation:XValidation:rule="self.type == 'Interval' ? has(self.interval) : !has(self.interval)",message="interval must be set if and only if type is Interval"
+type UpdatePolicy struct {
+	// +kubebuilder:default=Never
+	Type UpdatePolicyType `json:"type"`
+	// Interval between re-pulls, e.g. 24h. The first re-pull is due an interval after the model was pulled.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// Server is the configuration of the Ollama server, rendered into OLLAMA_* env vars of the Ollama container.
+// See https://github.com/ollama/ollama/blob/main/envconfig/config.go for details.
+// +kubebuilder:validation:XValidation:rule="!has(self.kvCacheType) || self.kvCacheType == 'f16' || (has(self.flashAttention) && self.flashAttention)",message="quantized kvCacheType requires flashAttention"
+type Server struct {
+	// KeepAlive is how long models stay loaded after a request, as a duration like 5m or a number of seconds.
+	// Negative values keep them loaded forever, which is the default.
+	// +kubebuilder:validation:Pattern=`^-?([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$|^-?[0-9]+$`
+	// +optional
+	KeepAlive string `json:"keepAlive,omitempty"`
+	// NumParallel is the maximum number of parallel requests each model processes.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	NumParallel *int32 `json:"numParallel,omitempty"`
+	// MaxQueue is the maximum number of queued requests, others are rejected.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxQueue *int32 `json:"maxQueue,omitempty"`
+	// FlashAttention enables flash attention, it reduces memory usage with large context sizes.
+	// +optional
+	FlashAttention *bool `json:"flashAttention,omitempty"`
+	// ContextLength is the default context length of the models.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ContextLength *int32 `json:"contextLength,omitempty"`
+	// KVCacheType is the quantization type of the K/V cache. Quantized types require flashAttention.
+	// +kubebuilder:validation:Enum=f16;q8_0;q4_0
+	// +optional
+	KVCacheType string `json:"kvCacheType,omitempty"`
+	// Debug enables debug logs of the server.
+	// +optional
+	Debug *bool `json:"debug,omitempty"`
+	// Origins allowed to make cross-origin requests, in addition to the default local ones.
+	// +listType=set
+	// +optional
+	Origins []string `json:"origins,omitempty"`
+}
+
+// Storage configures where Ollama keeps the models. By default every replica gets its own 20Gi ReadWriteOnce PVC.
+// +kubebuilder:validation:XValidation:rule="!has(self.emptyDir) || (!has(self.size) && !has(self.storageClassName) && !has(self.accessModes) && !has(self.retentionPolicy))",message="emptyDir can't be combined with size, storageClassName, accessModes or retentionPolicy"
+type Storage struct {
+	// Size of the PVC, defaults to 20Gi. Existing PVCs are expanded in place when it grows, shrinking is not supported.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// StorageClassName of the PVC, defaults to the cluster default storage class. It can't be changed, like AccessModes
+	// and EmptyDir, volume claim templates of the StatefulSet are immutable.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// AccessModes of the PVC, defaults to ReadWriteOnce.
+	// +optional
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+	// EmptyDir keeps the models in an emptyDir volume instead of a PVC, useful for throwaway models.
+	// Models are pulled again every time the pod is recreated.
+	// +optional
+	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+	// RetentionPolicy decides whether the PVCs are deleted together with the Model, defaults to Retain. It's set as
+	// whenDeleted of the StatefulSet's persistentVolumeClaimRetentionPolicy, the model controller deletes the PVCs
+	// in a finalizer too, in case the cluster doesn't support it.
+	// +optional
+	RetentionPolicy PVCRetentionPolicy `json:"retentionPolicy,omitempty"`
+}
+
+// ModelSource is where Ollama's models directory, with its blobs and manifests, is seeded from.
+// +kubebuilder:validation:XValidation:rule="[has(self.imageVolume), has(self.snapshot)].exists_one(x, x)",message="exactly one of imageVolume or snapshot must be set"
+type ModelSource struct {
+	// ImageVolume mounts an OCI image or artifact read-only over the models directory, it requires the ImageVolume
+	// feature of Kubernetes. Models missing in the image can't be pulled or created, they're reported as stalled
+	// with the NotInSeedImage reason.
+	// +optional
+	ImageVolume *corev1.ImageVolumeSource `json:"imageVolume,omitempty"`
+	// Snapshot references a VolumeSnapshot in the Model's namespace the PVCs are restored from. It can't be changed
+	// once set, volume claim templates of the StatefulSet are immutable.
+	// +optional
+	Snapshot *corev1.LocalObjectReference `json:"snapshot,omitempty"`
+}
+
+// PullMode decides how models are pulled.
+// +kubebuilder:validation:Enum=Operator;InitContainer
+type PullMode string
+
+const (
+	// PullModeOperator pulls the models through the Ollama API of every pod.
+	PullModeOperator PullMode = "Operator"
+	// PullModeInitContainer pulls the models in an init container of every pod before Ollama starts.
+	PullModeInitContainer PullMode = "InitContainer"
+)
+
+// PVCRetentionPolicy decides what happens to the PVCs once the Model is deleted.
+// +kubebuilder:validation:Enum=Retain;Delete
+type PVCRetentionPolicy string
+
+const (
+	// PVCRetentionPolicyRetain keeps the PVCs, a Model re-created with the same name reuses them.
+	PVCRetentionPolicyRetain PVCRetentionPolicy = "Retain"
+	// PVCRetentionPolicyDelete deletes the PVCs together with the Model.
+	PVCRetentionPolicyDelete PVCRetentionPolicy = "Delete"
+)
+
+// TypeServerConfigured is the condition type reporting whether statefulSetPatches override the configuration from spec.server.
+const TypeServerConfigured xpv2.ConditionType = "ServerConfigured"
+
+// Reasons of the ServerConfigured condition.
+const (
+	ReasonConfigured    xpv2.ConditionReason = "Configured"
+	ReasonPatchConflict xpv2.ConditionReason = "PatchConflict"
+)
+
+// TypeExposed is the condition type reporting whether the Ingress or HTTPRoute from spec.expose is admitted.
+const TypeExposed xpv2.ConditionType = "Exposed"
+
+// Reasons of the Exposed condition.
+const (
+	ReasonExposed        xpv2.ConditionReason = "Exposed"
+	ReasonExposePending  xpv2.ConditionReason = "Pending"
+	ReasonExposeRejected xpv2.ConditionReason = "Rejected"
+	ReasonNotExposed     xpv2.ConditionReason = "NotExposed"
+)
+
+// ModelLastUsedAnnotation holds the RFC3339 time the Model was last used by a Prompt, the prompt controller sets it
+// to wake up Models scaled to zero because of spec.idle.
+const ModelLastUsedAnnotation = "ollama.aerf.io/last-used"
+
+// ReasonScaledToZero is the reason of the Ready condition while the Model is scaled to zero because of spec.idle.
+const ReasonScaledToZero xpv2.ConditionReason = "ScaledToZero"
+
+// TypeSuspended is the condition type reporting whether the Model is suspended with spec.suspend.
+const TypeSuspended xpv2.ConditionType = "Suspended"
+
+// ModelPausedAnnotation stops the model controller from applying generated objects while it's set to "true",
+// e.g. to debug them. The changes it would apply are summarized in status.pendingChanges.
+const ModelPausedAnnotation = "ollama.aerf.io/paused"
+
+// TypePaused is the condition type reporting whether reconciliation is paused with ModelPausedAnnotation.
+const TypePaused xpv2.ConditionType = "Paused"
+
+// Reasons of the Suspended and Paused conditions.
+const (
+	ReasonSuspended xpv2.ConditionReason = "Suspended"
+	ReasonPaused    xpv2.ConditionReason = "Paused"
+	ReasonResumed   xpv2.ConditionReason = "Resumed"
+)
+
+// ModelCleanupFinalizer is set on Models with spec.cleanupOnDelete, see ModelSpec.CleanupOnDelete.
+const ModelCleanupFinalizer = "ollama.aerf.io/model-cleanup"
+
+// ModelVolumesFinalizer is set on Models with the Delete spec.storage.retentionPolicy, see Storage.RetentionPolicy.
+const ModelVolumesFinalizer = "ollama.aerf.io/volumes-cleanup"
+
+// TypeStorageExpanded is the condition type reporting whether the existing PVCs have the size requested in spec.storage.
+const TypeStorageExpanded xpv2.ConditionType = "StorageExpanded"
+
+// Reasons of the StorageExpanded condition.
+const (
+	ReasonExpanded           xpv2.ConditionReason = "Expanded"
+	ReasonExpanding          xpv2.ConditionReason = "Expanding"
+	ReasonShrinkNotSupported xpv2.ConditionReason = "ShrinkNotSupported"
+)
+
 // ModelStatus defines the observed state of Model
 type ModelStatus struct {
 	ConditionedStatus `json:",inline"`
@@ -40,9 +512,208 @@ type ModelStatus struct {
 	// which resulted in either a ready state, or stalled due to error
 	// it can not recover from without human intervention.
 	// +optional
-	ObservedGeneration int64               `json:"observedGeneration,omitempty"`
-	OllamaImage        string              `json:"ollamaImage,omitempty"`
+	ObservedGeneration int64  `json:"observedGeneration,omitempty"`
+	OllamaImage        string `json:"ollamaImage,omitempty"`
+	// OllamaModelDetails are the details of the first model from spec, kept for compatibility with single-model Models.
+	// See Models for details of every model.
 	OllamaModelDetails *OllamaModelDetails `json:"modelDetails,omitempty"`
+	// Models reports the state of every model from spec.model and spec.models.
+	// +listType=map
+	// +listMapKey=name
+	Models []ModelEntryStatus `json:"models,omitempty"`
+	// Pull reports the progress of the last model pull.
+	// +optional
+	Pull *PullProgress `json:"pull,omitempty"`
+	// PulledModels lists models pulled by the operator. Models that are no longer desired are deleted from Ollama
+	// and removed from this list.
+	// +listType=set
+	PulledModels []string `json:"pulledModels,omitempty"`
+	// ReadyReplicas is the number of pods that have all models pulled.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// Replicas reports the state of every Ollama pod.
+	// +listType=map
+	// +listMapKey=pod
+	Replicas []ReplicaStatus `json:"replicas,omitempty"`
+	// Expose reports the object generated from spec.expose, its readiness is reported in the Exposed condition.
+	// +optional
+	Expose *ExposeStatus `json:"expose,omitempty"`
+	// LastUsedTime is when the Model was last used, tracked for spec.idle.
+	// +optional
+	LastUsedTime *metav1.Time `json:"lastUsedTime,omitempty"`
+	// ScaledToZero is true while the StatefulSet is scaled to zero because of spec.idle.
+	// +optional
+	ScaledToZero bool `json:"scaledToZero,omitempty"`
+	// RetentionPolicy reports what happens to the PVCs once the Model is deleted, it's empty if the Model has no PVCs.
+	// +optional
+	RetentionPolicy PVCRetentionPolicy `json:"retentionPolicy,omitempty"`
+	// PendingChanges summarizes what the controller would change in generated objects, while reconciliation
+	// is paused with ModelPausedAnnotation.
+	// +listType=atomic
+	// +optional
+	PendingChanges []string `json:"pendingChanges,omitempty"`
+	// Auth reports the Secret with API keys generated for spec.auth.
+	// +optional
+	Auth *AuthStatus `json:"auth,omitempty"`
+}
+
+type AuthStatus struct {
+	// SecretName is the name of the Secret with API keys, stored under their names.
+	SecretName string `json:"secretName"`
+}
+
+type ExposeStatus struct {
+	// Kind of the generated object, Ingress or HTTPRoute.
+	Kind string `json:"kind"`
+	// Name of the generated object.
+	Name string `json:"name"`
+	// Addresses assigned to the Ingress by the ingress controller.
+	// +listType=atomic
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+	// URL of the Ollama API, known once the Ingress has an address. It's not reported for HTTPRoutes,
+	// the scheme depends on the listener of the Gateway.
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// PullProgress is the progress of a model pull, filled from the Ollama pull progress stream.
+type PullProgress struct {
+	// Model being pulled.
+	Model string `json:"model"`
+	// Pod the model is pulled on.
+	Pod string `json:"pod,omitempty"`
+	// CompletedBytes is the number of downloaded bytes of all layers seen so far.
+	CompletedBytes int64 `json:"completedBytes,omitempty"`
+	// TotalBytes is the size of all layers seen so far.
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+	// Percent of TotalBytes downloaded.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percent int32 `json:"percent"`
+	// Digest of the layer currently being downloaded.
+	Digest string `json:"digest,omitempty"`
+	// StartTime is the time the pull started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// EstimatedTimeRemaining based on the average download speed so far.
+	// +optional
+	EstimatedTimeRemaining *metav1.Duration `json:"estimatedTimeRemaining,omitempty"`
+	// LastStatus is the last status reported by Ollama, e.g. "pulling manifest" or "success".
+	LastStatus string `json:"lastStatus,omitempty"`
+}
+
+type ReplicaStatus struct {
+	// Pod is the name of the Ollama pod.
+	Pod string `json:"pod"`
+	// Ready is true once the pod is ready and has all models pulled.
+	Ready bool `json:"ready"`
+	// Models lists desired models already present on the pod.
+	Models []string `json:"models,omitempty"`
+	// CreatedModels maps names of models created on the pod to the hash of the Modelfile they were created from.
+	// +optional
+	CreatedModels map[string]string `json:"createdModels,omitempty"`
+	Message       string            `json:"message,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Pending;Pulling;Pulled;Failed
+type PullState string
+
+const (
+	PullStatePending PullState = "Pending"
+	PullStatePulling PullState = "Pulling"
+	PullStatePulled  PullState = "Pulled"
+	PullStateFailed  PullState = "Failed"
+)
+
+// PullFailureReason classifies pull failures that won't go away without a human intervention.
+// +kubebuilder:validation:Enum=ModelNotFound;RegistryUnreachable;DiskFull;DigestMismatch;NotInSeedImage
+type PullFailureReason string
+
+const (
+	// PullFailureModelNotFound means the model or its tag does not exist in the registry. It's not retried until spec changes.
+	PullFailureModelNotFound PullFailureReason = "ModelNotFound"
+	// PullFailureRegistryUnreachable means Ollama could not connect to the registry. It's retried with the maximum backoff.
+	PullFailureRegistryUnreachable PullFailureReason = "RegistryUnreachable"
+	// PullFailureDiskFull means there's no space left on the volume. It's not retried until spec changes.
+	PullFailureDiskFull PullFailureReason = "DiskFull"
+	// PullFailureDigestMismatch means the pulled model doesn't match the pinned digest. It's not retried until spec changes.
+	PullFailureDigestMismatch PullFailureReason = "DigestMismatch"
+	// PullFailureNotInSeedImage means the model is missing in the image volume from spec.source, which is read-only.
+	// It's not retried until spec changes.
+	PullFailureNotInSeedImage PullFailureReason = "NotInSeedImage"
+)
+
+// TypeStalled is the condition type reporting pull failures that need a human intervention, see PullFailureReason.
+const TypeStalled xpv2.ConditionType = "Stalled"
+
+// ReasonProgressing is the reason of the Stalled condition once the Model is no longer stalled.
+const ReasonProgressing xpv2.ConditionReason = "Progressing"
+
+type ModelEntryStatus struct {
+	Name string `json:"name"`
+	// Ready is true once the model has been pulled and its details were fetched from Ollama.
+	Ready     bool                `json:"ready"`
+	PullState PullState           `json:"pullState,omitempty"`
+	Message   string              `json:"message,omitempty"`
+	Details   *OllamaModelDetails `json:"details,omitempty"`
+	// PullAttempts is the number of failed attempts to pull the model since the last success or spec change.
+	PullAttempts int32 `json:"pullAttempts,omitempty"`
+	// LastPullError is the error of the last failed pull attempt.
+	LastPullError string `json:"lastPullError,omitempty"`
+	// FailureReason is set when the last pull failure needs a human intervention.
+	FailureReason PullFailureReason `json:"failureReason,omitempty"`
+	// FailedGeneration is the generation of the Model the last pull failure was observed at. Failed pulls are retried
+	// right away once the spec changes.
+	// +optional
+	FailedGeneration int64 `json:"failedGeneration,omitempty"`
+	// NextPullAttemptTime is the earliest time the failed pull is retried.
+	NextPullAttemptTime *metav1.Time `json:"nextPullAttemptTime,omitempty"`
+	// Registry the model is resolved from, e.g. https://registry.ollama.ai.
+	Registry string `json:"registry,omitempty"`
+	// Digest of the model reported by Ollama. Created models get a new digest whenever they're re-created.
+	Digest string `json:"digest,omitempty"`
+	// Size of the model on disk in bytes.
+	Size int64 `json:"size,omitempty"`
+	// ModifiedAt is the time the model was last pulled or created, as reported by Ollama.
+	// +optional
+	ModifiedAt *metav1.Time `json:"modifiedAt,omitempty"`
+	// CreateHash is the hash of the Modelfile the model was created from on all replicas.
+	CreateHash string `json:"createHash,omitempty"`
+	// SeededFrom is the spec.source the model was found in instead of being pulled, e.g. "snapshot phi3-weights".
+	// Seeded models are neither updated nor deleted by the operator. With spec.pullMode InitContainer only models from
+	// an image volume are reported as seeded, the ones restored from a snapshot can't be told apart from the pulled ones.
+	// +optional
+	SeededFrom string `json:"seededFrom,omitempty"`
+	// Load reports whether the model is loaded into memory, as reported by Ollama's running models endpoint.
+	// +optional
+	Load *ModelLoadStatus `json:"load,omitempty"`
+	// Update reports re-pulls of the model done according to spec.updatePolicy.
+	// +optional
+	Update *ModelUpdateStatus `json:"update,omitempty"`
+}
+
+type ModelUpdateStatus struct {
+	// LastCheckTime is the time the model was last re-pulled on all replicas, or pulled if it wasn't re-pulled yet.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+	// LastUpdateTime is the time a re-pull last changed the digest of the model.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+	// PreviousDigest is the digest of the model before the last update, the current one is reported in digest.
+	PreviousDigest string `json:"previousDigest,omitempty"`
+	// LastError is the error of the last failed re-pull. The previous version of the model is kept.
+	LastError string `json:"lastError,omitempty"`
+}
+
+type ModelLoadStatus struct {
+	// LoadedReplicas is the number of pods that have the model loaded into memory.
+	LoadedReplicas int32 `json:"loadedReplicas"`
+	// SizeVRAM is the number of bytes of the model loaded into VRAM on the first replica that has it loaded.
+	SizeVRAM int64 `json:"sizeVRAM,omitempty"`
+	// SizeRAM is the number of bytes of the model loaded into RAM on the first replica that has it loaded.
+	SizeRAM int64 `json:"sizeRAM,omitempty"`
+	// ExpiresAt is the time the model is unloaded on the first replica that has it loaded, unless it's used again.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
 }
 
 type OllamaModelDetails struct {
@@ -52,6 +723,27 @@ type OllamaModelDetails struct {
 	Format            string   `json:"format,omitempty"`
 	Family            string   `json:"family,omitempty"`
 	Families          []string `json:"families,omitempty"`
+	// Capabilities of the model, e.g. completion, vision, tools, embedding or thinking.
+	// +listType=set
+	Capabilities []string `json:"capabilities,omitempty"`
+	// Architecture of the model, e.g. llama.
+	Architecture string `json:"architecture,omitempty"`
+	// ContextLength is the maximum context length the model was trained with.
+	ContextLength int64 `json:"contextLength,omitempty"`
+	// License of the model.
+	License string `json:"license,omitempty"`
+	// Template is the default prompt template of the model.
+	Template string `json:"template,omitempty"`
+	// System is the default system prompt of the model.
+	System string `json:"system,omitempty"`
+	// Parameters are the default parameters of the model, in the Modelfile format.
+	Parameters string `json:"parameters,omitempty"`
+}
+
+// HasCapability reports whether the model has given capability. Models with unknown capabilities, reported by
+// Ollama versions that don't support them, are assumed to have all of them.
+func (in *OllamaModelDetails) HasCapability(capability string) bool {
+	return in == nil || len(in.Capabilities) == 0 || slices.Contains(in.Capabilities, capability)
 }
 
 // +genclient
@@ -60,6 +752,8 @@ type OllamaModelDetails struct {
 // +kubebuilder:printcolumn:name="MODEL",type="string",JSONPath=".spec.model"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="REPLICAS",type="integer",JSONPath=".status.readyReplicas"
+// +kubebuilder:printcolumn:name="PULL%",type="integer",JSONPath=".status.pull.percent"
 // +kubebuilder:printcolumn:name="PARAMETER_SIZE",type="string",JSONPath=".status.modelDetails.parameterSize"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:scope=Namespaced,categories={ollama}
@@ -85,6 +779,25 @@ func (in *Model) GetCondition(ct xpv2.ConditionType) xpv2.Condition {
 	return in.Status.GetCondition(ct)
 }
 
+// DesiredModels returns all models that should be served by the Ollama server, with spec.model shorthand first.
+func (in *Model) DesiredModels() []ModelEntry {
+	out := make([]ModelEntry, 0, len(in.Spec.Models)+1)
+	if in.Spec.Model != "" && !slices.ContainsFunc(in.Spec.Models, func(e ModelEntry) bool { return e.Name == in.Spec.Model }) {
+		out = append(out, ModelEntry{Name: in.Spec.Model, Digest: in.Spec.Digest, Create: in.Spec.Create})
+	}
+	return append(out, in.Spec.Models...)
+}
+
+// ModelStatusFor returns the status of the model with given name, or nil if it's not reported yet.
+func (in *Model) ModelStatusFor(name string) *ModelEntryStatus {
+	for i := range in.Status.Models {
+		if in.Status.Models[i].Name == name {
+			return &in.Status.Models[i]
+		}
+	}
+	return nil
+}
+
 // +kubebuilder:object:root=true
 
 // ModelList contains a list of Model
@@ -0,0 +1,85 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ModelAccessGrantSpec defines which namespaces may reference Models in the namespace of the grant.
+// Prompts referencing a Model in another namespace are rejected unless a grant in the Model's namespace allows it,
+// similarly to the ReferenceGrant of Gateway API.
+type ModelAccessGrantSpec struct {
+	// From lists namespaces allowed to reference the Models.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	From []ModelAccessGrantFrom `json:"from"`
+	// To lists the Models that may be referenced.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	To []ModelAccessGrantTo `json:"to"`
+}
+
+type ModelAccessGrantFrom struct {
+	// Namespace of the referencing Prompts.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+}
+
+type ModelAccessGrantTo struct {
+	// Name of the Model. All Models in the namespace are granted when empty.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced,categories={ollama}
+
+// ModelAccessGrant is the Schema for the modelaccessgrants API
+type ModelAccessGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ModelAccessGrantSpec `json:"spec,omitempty"`
+}
+
+// Allows returns true if the grant allows referencing the Model with given name from the namespace.
+func (in *ModelAccessGrant) Allows(fromNamespace, modelName string) bool {
+	return slices.ContainsFunc(in.Spec.From, func(f ModelAccessGrantFrom) bool { return f.Namespace == fromNamespace }) &&
+		slices.ContainsFunc(in.Spec.To, func(t ModelAccessGrantTo) bool { return t.Name == "" || t.Name == modelName })
+}
+
+// +kubebuilder:object:root=true
+
+// ModelAccessGrantList contains a list of ModelAccessGrant
+type ModelAccessGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ModelAccessGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(SchemeGroupVersion, &ModelAccessGrant{}, &ModelAccessGrantList{})
+		return nil
+	})
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// OllamaServerSpec defines the desired state of OllamaServer. Models reference it with spec.serverRef to share its
+// StatefulSet instead of creating their own. It isn't deleted until all of those Models are deleted.
+// +kubebuilder:validation:XValidation:rule="(has(self.storage) && has(self.storage.storageClassName)) == (has(oldSelf.storage) && has(oldSelf.storage.storageClassName)) && (!has(self.storage) || !has(self.storage.storageClassName) || self.storage.storageClassName == oldSelf.storage.storageClassName) && (has(self.storage) && has(self.storage.accessModes) ? self.storage.accessModes : ['ReadWriteOnce']) == (has(oldSelf.storage) && has(oldSelf.storage.accessModes) ? oldSelf.storage.accessModes : ['ReadWriteOnce']) && (has(self.storage) && has(self.storage.emptyDir)) == (has(oldSelf.storage) && has(oldSelf.storage.emptyDir))",message="storage.storageClassName, storage.accessModes and storage.emptyDir can't be changed, volume claim templates of the StatefulSet are immutable"
+type OllamaServerSpec struct {
+	// https://hub.docker.com/r/ollama/ollama/tags
+	OllamaImage string `json:"ollamaImage,omitempty"`
+	// Replicas is the number of Ollama pods. Every replica pulls the models into its own volume.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Storage configures the volume holding pulled models of all Models hosted on the server.
+	// +optional
+	Storage *Storage `json:"storage,omitempty"`
+	// RegistryCredentialsSecretRef references a Secret with the key pair the Ollama server uses to authenticate to the registry,
+	// see Registry.CredentialsSecretRef.
+	// +optional
+	RegistryCredentialsSecretRef *corev1.LocalObjectReference `json:"registryCredentialsSecretRef,omitempty"`
+	// Server configures the Ollama server.
+	// +optional
+	Server *Server `json:"server,omitempty"`
+	// NetworkPolicy generates a NetworkPolicy allowing ingress traffic to the Ollama pods only from the operator and the listed peers.
+	// +optional
+	NetworkPolicy      *NetworkPolicy `json:"networkPolicy,omitempty"`
+	StatefulSetPatches *Patches       `json:"statefulSetPatches,omitempty"`
+	ServicePatches     *Patches       `json:"servicePatches,omitempty"`
+}
+
+// OllamaServerInUseFinalizer is set on all OllamaServers, it blocks their deletion while Models reference them with
+// spec.serverRef, so the StatefulSet and the volumes aren't removed from under those Models.
+const OllamaServerInUseFinalizer = "ollama.aerf.io/server-in-use"
+
+// OllamaServerStatus defines the observed state of OllamaServer
+type OllamaServerStatus struct {
+	ConditionedStatus `json:",inline"`
+
+	// ObservedGeneration is the latest metadata.generation
+	// which resulted in either a ready state, or stalled due to error
+	// it can not recover from without human intervention.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// OllamaImage is the Ollama image the server runs.
+	OllamaImage string `json:"ollamaImage,omitempty"`
+	// ReadyReplicas is the number of ready Ollama pods.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// Models lists Models hosted on the server.
+	// +listType=map
+	// +listMapKey=name
+	Models []HostedModel `json:"models,omitempty"`
+}
+
+type HostedModel struct {
+	// Name of the Model in the namespace of the OllamaServer.
+	Name string `json:"name"`
+	// Models served for the Model.
+	// +listType=set
+	Models []string `json:"models,omitempty"`
+	// Ready is true once all models are pulled on all replicas.
+	Ready bool `json:"ready"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="REPLICAS",type="integer",JSONPath=".status.readyReplicas"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced,categories={ollama}
+
+// OllamaServer is the Schema for the ollamaservers API
+type OllamaServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OllamaServerSpec   `json:"spec,omitempty"`
+	Status OllamaServerStatus `json:"status,omitempty"`
+}
+
+func (in *OllamaServer) SetConditionsWithObservedGeneration(c ...xpv2.Condition) {
+	for i := range c {
+		c[i].ObservedGeneration = in.Generation
+	}
+
+	in.Status.SetConditions(c...)
+}
+
+func (in *OllamaServer) GetCondition(ct xpv2.ConditionType) xpv2.Condition {
+	return in.Status.GetCondition(ct)
+}
+
+// +kubebuilder:object:root=true
+
+// OllamaServerList contains a list of OllamaServer
+type OllamaServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OllamaServer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(SchemeGroupVersion, &OllamaServer{}, &OllamaServerList{})
+		return nil
+	})
+}
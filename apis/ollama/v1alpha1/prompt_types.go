@@ -71,8 +71,14 @@ type ModelRef struct {
 	Name string `json:"name"`
 	// defaults to prompt namespace
 	Namespace string `json:"namespace,omitempty"`
+	// Model selects one of the models served by the referenced Model, e.g. phi3. Defaults to the first one.
+	Model string `json:"model,omitempty"`
 }
 
+// ReasonWakingModel is the reason of the Ready condition while the Prompt waits for its Model to scale up
+// from zero, see ModelSpec.Idle.
+const ReasonWakingModel xpv2.ConditionReason = "WakingModel"
+
 // PromptStatus defines the observed state of Model
 type PromptStatus struct {
 	ConditionedStatus `json:",inline"`
@@ -6,9 +6,68 @@ package v1alpha1
 
 import (
 	"github.com/crossplane/crossplane/apis/v2/core/v2"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKey) DeepCopyInto(out *APIKey) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKey.
+func (in *APIKey) DeepCopy() *APIKey {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Auth) DeepCopyInto(out *Auth) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]APIKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Auth.
+func (in *Auth) DeepCopy() *Auth {
+	if in == nil {
+		return nil
+	}
+	out := new(Auth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthStatus) DeepCopyInto(out *AuthStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthStatus.
+func (in *AuthStatus) DeepCopy() *AuthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConditionedStatus) DeepCopyInto(out *ConditionedStatus) {
 	*out = *in
@@ -62,6 +121,96 @@ func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Expose) DeepCopyInto(out *Expose) {
+	*out = *in
+	if in.ClassName != nil {
+		in, out := &in.ClassName, &out.ClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TLSSecretRef != nil {
+		in, out := &in.TLSSecretRef, &out.TLSSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.GatewayRef != nil {
+		in, out := &in.GatewayRef, &out.GatewayRef
+		*out = new(GatewayReference)
+		**out = **in
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = new(Patches)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Expose.
+func (in *Expose) DeepCopy() *Expose {
+	if in == nil {
+		return nil
+	}
+	out := new(Expose)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposeStatus) DeepCopyInto(out *ExposeStatus) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeStatus.
+func (in *ExposeStatus) DeepCopy() *ExposeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayReference) DeepCopyInto(out *GatewayReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayReference.
+func (in *GatewayReference) DeepCopy() *GatewayReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedModel) DeepCopyInto(out *HostedModel) {
+	*out = *in
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedModel.
+func (in *HostedModel) DeepCopy() *HostedModel {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedModel)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageData) DeepCopyInto(out *ImageData) {
 	*out = *in
@@ -197,31 +346,72 @@ func (in *Model) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelList) DeepCopyInto(out *ModelList) {
+func (in *ModelAccessGrant) DeepCopyInto(out *ModelAccessGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelAccessGrant.
+func (in *ModelAccessGrant) DeepCopy() *ModelAccessGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelAccessGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelAccessGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelAccessGrantFrom) DeepCopyInto(out *ModelAccessGrantFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelAccessGrantFrom.
+func (in *ModelAccessGrantFrom) DeepCopy() *ModelAccessGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelAccessGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelAccessGrantList) DeepCopyInto(out *ModelAccessGrantList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Model, len(*in))
+		*out = make([]ModelAccessGrant, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelList.
-func (in *ModelList) DeepCopy() *ModelList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelAccessGrantList.
+func (in *ModelAccessGrantList) DeepCopy() *ModelAccessGrantList {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelList)
+	out := new(ModelAccessGrantList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ModelList) DeepCopyObject() runtime.Object {
+func (in *ModelAccessGrantList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -229,156 +419,208 @@ func (in *ModelList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRef) DeepCopyInto(out *ModelRef) {
+func (in *ModelAccessGrantSpec) DeepCopyInto(out *ModelAccessGrantSpec) {
 	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]ModelAccessGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]ModelAccessGrantTo, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRef.
-func (in *ModelRef) DeepCopy() *ModelRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelAccessGrantSpec.
+func (in *ModelAccessGrantSpec) DeepCopy() *ModelAccessGrantSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRef)
+	out := new(ModelAccessGrantSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
+func (in *ModelAccessGrantTo) DeepCopyInto(out *ModelAccessGrantTo) {
 	*out = *in
-	if in.StatefulSetPatches != nil {
-		in, out := &in.StatefulSetPatches, &out.StatefulSetPatches
-		*out = new(Patches)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ServicePatches != nil {
-		in, out := &in.ServicePatches, &out.ServicePatches
-		*out = new(Patches)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
-func (in *ModelSpec) DeepCopy() *ModelSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelAccessGrantTo.
+func (in *ModelAccessGrantTo) DeepCopy() *ModelAccessGrantTo {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelSpec)
+	out := new(ModelAccessGrantTo)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelStatus) DeepCopyInto(out *ModelStatus) {
+func (in *ModelCreate) DeepCopyInto(out *ModelCreate) {
 	*out = *in
-	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
-	if in.OllamaModelDetails != nil {
-		in, out := &in.OllamaModelDetails, &out.OllamaModelDetails
-		*out = new(OllamaModelDetails)
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]ModelFile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Adapters != nil {
+		in, out := &in.Adapters, &out.Adapters
+		*out = make([]ModelFile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ModelParameter, len(*in))
+		copy(*out, *in)
+	}
+	if in.Messages != nil {
+		in, out := &in.Messages, &out.Messages
+		*out = make([]ModelMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.License != nil {
+		in, out := &in.License, &out.License
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ModelfileRef != nil {
+		in, out := &in.ModelfileRef, &out.ModelfileRef
+		*out = new(v1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatus.
-func (in *ModelStatus) DeepCopy() *ModelStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelCreate.
+func (in *ModelCreate) DeepCopy() *ModelCreate {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelStatus)
+	out := new(ModelCreate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OllamaModelDetails) DeepCopyInto(out *OllamaModelDetails) {
+func (in *ModelEntry) DeepCopyInto(out *ModelEntry) {
 	*out = *in
-	if in.Families != nil {
-		in, out := &in.Families, &out.Families
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Create != nil {
+		in, out := &in.Create, &out.Create
+		*out = new(ModelCreate)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OllamaModelDetails.
-func (in *OllamaModelDetails) DeepCopy() *OllamaModelDetails {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelEntry.
+func (in *ModelEntry) DeepCopy() *ModelEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(OllamaModelDetails)
+	out := new(ModelEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Patches) DeepCopyInto(out *Patches) {
+func (in *ModelEntryStatus) DeepCopyInto(out *ModelEntryStatus) {
 	*out = *in
-	in.JSONPatch.DeepCopyInto(&out.JSONPatch)
-	in.MergePatch.DeepCopyInto(&out.MergePatch)
+	if in.Details != nil {
+		in, out := &in.Details, &out.Details
+		*out = new(OllamaModelDetails)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NextPullAttemptTime != nil {
+		in, out := &in.NextPullAttemptTime, &out.NextPullAttemptTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ModifiedAt != nil {
+		in, out := &in.ModifiedAt, &out.ModifiedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Load != nil {
+		in, out := &in.Load, &out.Load
+		*out = new(ModelLoadStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Update != nil {
+		in, out := &in.Update, &out.Update
+		*out = new(ModelUpdateStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Patches.
-func (in *Patches) DeepCopy() *Patches {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelEntryStatus.
+func (in *ModelEntryStatus) DeepCopy() *ModelEntryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Patches)
+	out := new(ModelEntryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Prompt) DeepCopyInto(out *Prompt) {
+func (in *ModelFile) DeepCopyInto(out *ModelFile) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(PersistentVolumeClaimFile)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Prompt.
-func (in *Prompt) DeepCopy() *Prompt {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelFile.
+func (in *ModelFile) DeepCopy() *ModelFile {
 	if in == nil {
 		return nil
 	}
-	out := new(Prompt)
+	out := new(ModelFile)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Prompt) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PromptList) DeepCopyInto(out *PromptList) {
+func (in *ModelList) DeepCopyInto(out *ModelList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Prompt, len(*in))
+		*out = make([]Model, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromptList.
-func (in *PromptList) DeepCopy() *PromptList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelList.
+func (in *ModelList) DeepCopy() *ModelList {
 	if in == nil {
 		return nil
 	}
-	out := new(PromptList)
+	out := new(ModelList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PromptList) DeepCopyObject() runtime.Object {
+func (in *ModelList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -386,31 +628,599 @@ func (in *PromptList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PromptResponseMeta) DeepCopyInto(out *PromptResponseMeta) {
+func (in *ModelLoadStatus) DeepCopyInto(out *ModelLoadStatus) {
 	*out = *in
-	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromptResponseMeta.
-func (in *PromptResponseMeta) DeepCopy() *PromptResponseMeta {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelLoadStatus.
+func (in *ModelLoadStatus) DeepCopy() *ModelLoadStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PromptResponseMeta)
+	out := new(ModelLoadStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PromptResponseMetrics) DeepCopyInto(out *PromptResponseMetrics) {
+func (in *ModelMessage) DeepCopyInto(out *ModelMessage) {
 	*out = *in
-	out.TotalDuration = in.TotalDuration
-	out.LoadDuration = in.LoadDuration
-	out.PromptEvalDuration = in.PromptEvalDuration
-	out.EvalDuration = in.EvalDuration
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromptResponseMetrics.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelMessage.
+func (in *ModelMessage) DeepCopy() *ModelMessage {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelMessage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelParameter) DeepCopyInto(out *ModelParameter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelParameter.
+func (in *ModelParameter) DeepCopy() *ModelParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRef) DeepCopyInto(out *ModelRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRef.
+func (in *ModelRef) DeepCopy() *ModelRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelSource) DeepCopyInto(out *ModelSource) {
+	*out = *in
+	if in.ImageVolume != nil {
+		in, out := &in.ImageVolume, &out.ImageVolume
+		*out = new(v1.ImageVolumeSource)
+		**out = **in
+	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSource.
+func (in *ModelSource) DeepCopy() *ModelSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
+	*out = *in
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]ModelEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Create != nil {
+		in, out := &in.Create, &out.Create
+		*out = new(ModelCreate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServerRef != nil {
+		in, out := &in.ServerRef, &out.ServerRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(Storage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Registry != nil {
+		in, out := &in.Registry, &out.Registry
+		*out = new(Registry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(ModelSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdatePolicy != nil {
+		in, out := &in.UpdatePolicy, &out.UpdatePolicy
+		*out = new(UpdatePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Idle != nil {
+		in, out := &in.Idle, &out.Idle
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Server != nil {
+		in, out := &in.Server, &out.Server
+		*out = new(Server)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(Auth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(Expose)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StatefulSetPatches != nil {
+		in, out := &in.StatefulSetPatches, &out.StatefulSetPatches
+		*out = new(Patches)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServicePatches != nil {
+		in, out := &in.ServicePatches, &out.ServicePatches
+		*out = new(Patches)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
+func (in *ModelSpec) DeepCopy() *ModelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelStatus) DeepCopyInto(out *ModelStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.OllamaModelDetails != nil {
+		in, out := &in.OllamaModelDetails, &out.OllamaModelDetails
+		*out = new(OllamaModelDetails)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]ModelEntryStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Pull != nil {
+		in, out := &in.Pull, &out.Pull
+		*out = new(PullProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PulledModels != nil {
+		in, out := &in.PulledModels, &out.PulledModels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]ReplicaStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(ExposeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastUsedTime != nil {
+		in, out := &in.LastUsedTime, &out.LastUsedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(AuthStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatus.
+func (in *ModelStatus) DeepCopy() *ModelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelUpdateStatus) DeepCopyInto(out *ModelUpdateStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelUpdateStatus.
+func (in *ModelUpdateStatus) DeepCopy() *ModelUpdateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelUpdateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = new(Patches)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicy.
+func (in *NetworkPolicy) DeepCopy() *NetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyPeer) DeepCopyInto(out *NetworkPolicyPeer) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyPeer.
+func (in *NetworkPolicyPeer) DeepCopy() *NetworkPolicyPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OllamaModelDetails) DeepCopyInto(out *OllamaModelDetails) {
+	*out = *in
+	if in.Families != nil {
+		in, out := &in.Families, &out.Families
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OllamaModelDetails.
+func (in *OllamaModelDetails) DeepCopy() *OllamaModelDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(OllamaModelDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OllamaServer) DeepCopyInto(out *OllamaServer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OllamaServer.
+func (in *OllamaServer) DeepCopy() *OllamaServer {
+	if in == nil {
+		return nil
+	}
+	out := new(OllamaServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OllamaServer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OllamaServerList) DeepCopyInto(out *OllamaServerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OllamaServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OllamaServerList.
+func (in *OllamaServerList) DeepCopy() *OllamaServerList {
+	if in == nil {
+		return nil
+	}
+	out := new(OllamaServerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OllamaServerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OllamaServerSpec) DeepCopyInto(out *OllamaServerSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(Storage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegistryCredentialsSecretRef != nil {
+		in, out := &in.RegistryCredentialsSecretRef, &out.RegistryCredentialsSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Server != nil {
+		in, out := &in.Server, &out.Server
+		*out = new(Server)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StatefulSetPatches != nil {
+		in, out := &in.StatefulSetPatches, &out.StatefulSetPatches
+		*out = new(Patches)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServicePatches != nil {
+		in, out := &in.ServicePatches, &out.ServicePatches
+		*out = new(Patches)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OllamaServerSpec.
+func (in *OllamaServerSpec) DeepCopy() *OllamaServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OllamaServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OllamaServerStatus) DeepCopyInto(out *OllamaServerStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]HostedModel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OllamaServerStatus.
+func (in *OllamaServerStatus) DeepCopy() *OllamaServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OllamaServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Patches) DeepCopyInto(out *Patches) {
+	*out = *in
+	in.JSONPatch.DeepCopyInto(&out.JSONPatch)
+	in.MergePatch.DeepCopyInto(&out.MergePatch)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Patches.
+func (in *Patches) DeepCopy() *Patches {
+	if in == nil {
+		return nil
+	}
+	out := new(Patches)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentVolumeClaimFile) DeepCopyInto(out *PersistentVolumeClaimFile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimFile.
+func (in *PersistentVolumeClaimFile) DeepCopy() *PersistentVolumeClaimFile {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentVolumeClaimFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Prompt) DeepCopyInto(out *Prompt) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Prompt.
+func (in *Prompt) DeepCopy() *Prompt {
+	if in == nil {
+		return nil
+	}
+	out := new(Prompt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Prompt) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromptList) DeepCopyInto(out *PromptList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Prompt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromptList.
+func (in *PromptList) DeepCopy() *PromptList {
+	if in == nil {
+		return nil
+	}
+	out := new(PromptList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PromptList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromptResponseMeta) DeepCopyInto(out *PromptResponseMeta) {
+	*out = *in
+	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromptResponseMeta.
+func (in *PromptResponseMeta) DeepCopy() *PromptResponseMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(PromptResponseMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromptResponseMetrics) DeepCopyInto(out *PromptResponseMetrics) {
+	*out = *in
+	out.TotalDuration = in.TotalDuration
+	out.LoadDuration = in.LoadDuration
+	out.PromptEvalDuration = in.PromptEvalDuration
+	out.EvalDuration = in.EvalDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromptResponseMetrics.
 func (in *PromptResponseMetrics) DeepCopy() *PromptResponseMetrics {
 	if in == nil {
 		return nil
@@ -469,3 +1279,174 @@ func (in *PromptStatus) DeepCopy() *PromptStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullProgress) DeepCopyInto(out *PullProgress) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EstimatedTimeRemaining != nil {
+		in, out := &in.EstimatedTimeRemaining, &out.EstimatedTimeRemaining
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullProgress.
+func (in *PullProgress) DeepCopy() *PullProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(PullProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Registry) DeepCopyInto(out *Registry) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Registry.
+func (in *Registry) DeepCopy() *Registry {
+	if in == nil {
+		return nil
+	}
+	out := new(Registry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaStatus) DeepCopyInto(out *ReplicaStatus) {
+	*out = *in
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CreatedModels != nil {
+		in, out := &in.CreatedModels, &out.CreatedModels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaStatus.
+func (in *ReplicaStatus) DeepCopy() *ReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Server) DeepCopyInto(out *Server) {
+	*out = *in
+	if in.NumParallel != nil {
+		in, out := &in.NumParallel, &out.NumParallel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxQueue != nil {
+		in, out := &in.MaxQueue, &out.MaxQueue
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FlashAttention != nil {
+		in, out := &in.FlashAttention, &out.FlashAttention
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ContextLength != nil {
+		in, out := &in.ContextLength, &out.ContextLength
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Origins != nil {
+		in, out := &in.Origins, &out.Origins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Server.
+func (in *Server) DeepCopy() *Server {
+	if in == nil {
+		return nil
+	}
+	out := new(Server)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Storage) DeepCopyInto(out *Storage) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.EmptyDir != nil {
+		in, out := &in.EmptyDir, &out.EmptyDir
+		*out = new(v1.EmptyDirVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Storage.
+func (in *Storage) DeepCopy() *Storage {
+	if in == nil {
+		return nil
+	}
+	out := new(Storage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdatePolicy) DeepCopyInto(out *UpdatePolicy) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdatePolicy.
+func (in *UpdatePolicy) DeepCopy() *UpdatePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdatePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
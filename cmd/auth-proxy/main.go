@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"github.com/go-logr/logr"
+	"k8s.io/klog/v2"
+
+	"aerf.io/ollama-operator/internal/authproxy"
+)
+
+var cli struct {
+	Listen         string        `default:":11434" help:"Address the proxy listens on"`
+	Upstream       *url.URL      `default:"http://127.0.0.1:11433" help:"URL of the Ollama server"`
+	Config         string        `default:"/etc/auth-proxy/config.json" type:"path" help:"Path to the file with API keys"`
+	ReloadInterval time.Duration `default:"10s" help:"How often the file with API keys is checked for changes"`
+}
+
+func main() {
+	kong.Parse(&cli,
+		kong.Name("auth-proxy"),
+		kong.Description("A reverse proxy checking API keys in front of Ollama"),
+		kong.UsageOnError(),
+	)
+	if err := run(); err != nil {
+		klog.Background().Error(err, "failed to run the auth proxy")
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	log := klog.Background()
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	data, err := os.ReadFile(cli.Config)
+	if err != nil {
+		return fmt.Errorf("while reading config: %s", err)
+	}
+	config, err := authproxy.ParseConfig(data)
+	if err != nil {
+		return err
+	}
+	var current atomic.Pointer[authproxy.Config]
+	current.Store(config)
+	go reload(ctx, log, data, &current)
+
+	server := &http.Server{
+		Addr:              cli.Listen,
+		Handler:           authproxy.NewHandler(cli.Upstream, current.Load, log),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer shutdownCancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Error(err, "failed to shutdown the server")
+		}
+	}()
+
+	log.Info("Starting auth proxy", "listen", cli.Listen, "upstream", cli.Upstream.String(), "keys", len(config.Keys))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// reload polls the config file, kubelet updates mounted Secrets in place when the operator changes the keys.
+func reload(ctx context.Context, log logr.Logger, last []byte, current *atomic.Pointer[authproxy.Config]) {
+	ticker := time.NewTicker(cli.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		data, err := os.ReadFile(cli.Config)
+		if err != nil {
+			log.Error(err, "failed to read config, keeping the previous one")
+			continue
+		}
+		if bytes.Equal(data, last) {
+			continue
+		}
+		config, err := authproxy.ParseConfig(data)
+		if err != nil {
+			log.Error(err, "failed to parse config, keeping the previous one")
+			continue
+		}
+		current.Store(config)
+		last = data
+		log.Info("Reloaded config", "keys", len(config.Keys))
+	}
+}
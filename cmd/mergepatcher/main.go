@@ -42,17 +42,20 @@ func main() {
 		modelNoPatches := model.DeepCopy()
 		modelNoPatches.Spec.ServicePatches = nil
 		modelNoPatches.Spec.StatefulSetPatches = nil
+		if modelNoPatches.Spec.NetworkPolicy != nil {
+			modelNoPatches.Spec.NetworkPolicy.Patches = nil
+		}
 
-		noPatchesResources, err := modelcontroller.Resources(modelNoPatches)
+		noPatchesResources, err := modelcontroller.Resources(modelNoPatches, modelcontroller.Operator{})
 		kctx.FatalIfErrorf(err, "unable to create resources out of model instance")
 
-		resources, err := modelcontroller.Resources(model)
+		resources, err := modelcontroller.Resources(model, modelcontroller.Operator{})
 		kctx.FatalIfErrorf(err, "unable to create resources out of model instance")
 
 		fmt.Println(gocmp.Diff(noPatchesResources, resources))
 
 	} else {
-		res, err := modelcontroller.Resources(model)
+		res, err := modelcontroller.Resources(model, modelcontroller.Operator{})
 		kctx.FatalIfErrorf(err, "unable to create resource out of model instance")
 		kctx.FatalIfErrorf(printObjects(res), "unable to print child objects")
 	}
@@ -25,6 +25,7 @@ import (
 	"net/http/pprof"
 	"os"
 	stdruntime "runtime"
+	"slices"
 	"strconv"
 	"time"
 
@@ -40,12 +41,17 @@ import (
 	"go.uber.org/atomic"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/server/routes"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	cliflag "k8s.io/component-base/cli/flag"
@@ -66,7 +72,9 @@ import (
 	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
 	"aerf.io/ollama-operator/internal/commonmeta"
 	"aerf.io/ollama-operator/internal/controllers/model"
+	"aerf.io/ollama-operator/internal/controllers/ollamaserver"
 	"aerf.io/ollama-operator/internal/controllers/prompt"
+	"aerf.io/ollama-operator/internal/defaults"
 	"aerf.io/ollama-operator/internal/restconfig"
 
 	"aerf.io/k8sutils/k8stracing"
@@ -98,12 +106,16 @@ var (
 	restConfigQPS               float32 = 100
 	restConfigBurst                     = 300
 	dstGroupKindConcurrency             = map[string]int{
-		ollamav1alpha1.ModelGroupVersionKind.GroupKind().String():  10,
-		ollamav1alpha1.PromptGroupVersionKind.GroupKind().String(): 10,
+		ollamav1alpha1.ModelGroupVersionKind.GroupKind().String():        10,
+		ollamav1alpha1.PromptGroupVersionKind.GroupKind().String():       10,
+		ollamav1alpha1.OllamaServerGroupVersionKind.GroupKind().String(): 10,
 	}
 	groupKindConcurrency               = maps.Clone(dstGroupKindConcurrency)
 	tracingEndpoint                    = ""
 	tracingSampingRatePerMillion int32 = 0
+	operatorNamespace                  = os.Getenv("POD_NAMESPACE")
+	operatorPodLabels                  = map[string]string{"app.kubernetes.io/name": "ollama-operator"}
+	authProxyImage                     = defaults.AuthProxyImage
 
 	blockProfileRate     = 0
 	cpuProfileRate       = 0
@@ -146,6 +158,15 @@ func initFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&watchNamespace, "namespace", watchNamespace,
 		"Namespace that the operator watches to reconcile ollama.aerf.io objects. If unspecified, the operator watches objects across all namespaces.")
 
+	fs.StringVar(&operatorNamespace, "operator-namespace", operatorNamespace,
+		"Namespace of the operator pods, allowed by generated NetworkPolicies. Defaults to the POD_NAMESPACE env var, NetworkPolicies are not generated if it's empty.")
+
+	fs.StringToStringVar(&operatorPodLabels, "operator-pod-labels", operatorPodLabels,
+		"Labels of the operator pods, allowed by generated NetworkPolicies.")
+
+	fs.StringVar(&authProxyImage, "auth-proxy-image", authProxyImage,
+		"Image of the auth proxy sidecar injected into Ollama pods of Models with spec.auth.")
+
 	fs.IntVar(&profilerPort, "profiler-port", profilerPort,
 		"Port to expose the pprof profiler")
 
@@ -256,24 +277,55 @@ func mainErr() error {
 		restConfigBurst,
 		"ollama-operator")
 
+	gatewayAPIInstalled, err := servesKind(restCfg, model.HTTPRouteGroupVersionKind)
+	if err != nil {
+		return fmt.Errorf("failed to check whether Gateway API is installed: %s", err)
+	}
+
 	containsImageSelector := labels.SelectorFromSet(map[string]string{"ollama.aerf.io/contains-image": "true"})
 	cacheOpts := cache.Options{
 		ReaderFailOnMissingInformer: true, // let's try to ensure we understand what resources are cached by disabling auto-cache-creation and doing it manually here
 		ByObject: map[client.Object]cache.ByObject{
-			&ollamav1alpha1.Model{}:  {},
-			&ollamav1alpha1.Prompt{}: {},
+			&ollamav1alpha1.Model{}:            {},
+			&ollamav1alpha1.Prompt{}:           {},
+			&ollamav1alpha1.OllamaServer{}:     {},
+			&ollamav1alpha1.ModelAccessGrant{}: {},
 			/*
 				exposes ollama sts
 			*/
 			&corev1.Service{}: {
 				Label: labels.SelectorFromSet(commonmeta.ManagedByLabel),
 			},
+			/*
+				exposes ollama service outside of the cluster, see spec.expose
+			*/
+			&networkingv1.Ingress{}: {
+				Label: labels.SelectorFromSet(commonmeta.ManagedByLabel),
+			},
+			/*
+				restricts access to ollama pods, see spec.networkPolicy
+			*/
+			&networkingv1.NetworkPolicy{}: {
+				Label: labels.SelectorFromSet(commonmeta.ManagedByLabel),
+			},
 			/*
 				runs ollama container
 			*/
 			&appsv1.StatefulSet{}: {
 				Label: labels.SelectorFromSet(commonmeta.ManagedByLabel),
 			},
+			/*
+				ollama pods, model controller talks to each of them directly
+			*/
+			&corev1.Pod{}: {
+				Label: labels.SelectorFromSet(commonmeta.ManagedByLabel),
+			},
+			/*
+				created by ollama sts, expanded by model and ollamaserver controllers
+			*/
+			&corev1.PersistentVolumeClaim{}: {
+				Label: labels.SelectorFromSet(commonmeta.ManagedByLabel),
+			},
 			/*
 				used to read image data in Prompt controller
 			*/
@@ -288,6 +340,14 @@ func mainErr() error {
 			},
 		},
 	}
+	if gatewayAPIInstalled {
+		// the cache can't be started with HTTPRoutes if their CRD is missing, so they're added only if it's installed
+		httpRoute := &unstructured.Unstructured{}
+		httpRoute.SetGroupVersionKind(model.HTTPRouteGroupVersionKind)
+		cacheOpts.ByObject[httpRoute] = cache.ByObject{
+			Label: labels.SelectorFromSet(commonmeta.ManagedByLabel),
+		}
+	}
 	if watchNamespace != "" {
 		cacheOpts.DefaultNamespaces = map[string]cache.Config{
 			watchNamespace: {},
@@ -377,7 +437,8 @@ func mainErr() error {
 		),
 	}
 
-	if err := model.SetupWithManager(mgr, httpCli, tp); err != nil {
+	operator := model.Operator{Namespace: operatorNamespace, PodLabels: operatorPodLabels, AuthProxyImage: authProxyImage}
+	if err := model.SetupWithManager(mgr, httpCli, tp, operator); err != nil {
 		return fmt.Errorf("failed to setup Model controller: %s", err)
 	}
 
@@ -385,6 +446,10 @@ func mainErr() error {
 		return fmt.Errorf("failed to setup Prompt controller: %s", err)
 	}
 
+	if err := ollamaserver.SetupWithManager(mgr, tp, operator); err != nil {
+		return fmt.Errorf("failed to setup OllamaServer controller: %s", err)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		return fmt.Errorf("failed to add healthz checker: %s", err)
 	}
@@ -417,3 +482,19 @@ func mainErr() error {
 	setupLog.Info("starting manager")
 	return errors.Wrap(mgr.Start(ctx), "manager problem running manager")
 }
+
+// servesKind checks with the discovery API whether the cluster serves the kind, e.g. whether its CRD is installed.
+func servesKind(restCfg *rest.Config, gvk schema.GroupVersionKind) (bool, error) {
+	discoveryCli, err := discovery.NewDiscoveryClientForConfig(restCfg)
+	if err != nil {
+		return false, err
+	}
+	resources, err := discoveryCli.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(resources.APIResources, func(res metav1.APIResource) bool { return res.Kind == gvk.Kind }), nil
+}
@@ -18,5 +18,6 @@ fi
 --create-namespace \
 --set-json additionalOperatorArgs='["-v=3"]' \
 --set "image.tag=$1" \
+--set "authProxyImage.tag=$1" \
 --atomic \
 --take-ownership
@@ -0,0 +1,69 @@
+// Package authproxy implements the reverse proxy checking API keys in front of Ollama, injected as a sidecar
+// for spec.auth of Models, and its configuration rendered by the operator.
+package authproxy
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ConfigFileName is the key of the configuration in the generated Secret, and its file name in the sidecar.
+const ConfigFileName = "config.json"
+
+// OperatorKeyName is the name of the operator's key in the configuration, and its key in the generated Secret.
+// Each Model has its own operator key allowing every endpoint, so it doesn't give access to other Models.
+const OperatorKeyName = "ollama-operator"
+
+// Config lists keys accepted by the proxy. Keys are stored hashed, the proxy sidecar doesn't get the keys themselves.
+type Config struct {
+	Keys []Key `json:"keys"`
+}
+
+type Key struct {
+	Name string `json:"name"`
+	// SHA256 is the hex encoded sha256 of the key.
+	SHA256 string `json:"sha256"`
+	// Endpoints the key may call. A trailing * matches any suffix, a sole * matches every endpoint.
+	Endpoints []string `json:"endpoints"`
+}
+
+// Hash returns the hex encoded sha256 of the key, as stored in Config.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseConfig parses the configuration file of the proxy.
+func ParseConfig(data []byte) (*Config, error) {
+	config := &Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid auth proxy config: %s", err)
+	}
+	return config, nil
+}
+
+// Lookup returns the configured key matching the presented one, or nil if it's unknown.
+func (c *Config) Lookup(key string) *Key {
+	hash := []byte(Hash(key))
+	for i := range c.Keys {
+		if subtle.ConstantTimeCompare(hash, []byte(c.Keys[i].SHA256)) == 1 {
+			return &c.Keys[i]
+		}
+	}
+	return nil
+}
+
+// Allows reports whether the key may call the endpoint.
+func (k *Key) Allows(path string) bool {
+	return slices.ContainsFunc(k.Endpoints, func(endpoint string) bool {
+		if prefix, ok := strings.CutSuffix(endpoint, "*"); ok {
+			return strings.HasPrefix(path, prefix)
+		}
+		return path == endpoint
+	})
+}
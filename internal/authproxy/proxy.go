@@ -0,0 +1,61 @@
+package authproxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// ConfigFunc returns the current configuration, it's reloaded while the proxy runs.
+type ConfigFunc func() *Config
+
+// NewHandler returns the proxy forwarding requests with an allowed API key to upstream, the Ollama server.
+// "GET /" answers "Ollama is running" and is left unauthenticated, so that probes keep working.
+func NewHandler(upstream *url.URL, config ConfigFunc, log logr.Logger) http.Handler {
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(upstream)
+			r.Out.Header.Del("Authorization")
+		},
+		// stream responses of pulls and generations as they come
+		FlushInterval: -1,
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// clean the path before matching it, so that e.g. /v1/../api/pull doesn't pass as /v1/*
+		cleaned := path.Clean("/" + r.URL.Path)
+		r.URL.Path, r.URL.RawPath = cleaned, ""
+		if cleaned == "/" && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+			proxy.ServeHTTP(w, r)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			writeError(w, http.StatusUnauthorized, "missing API key, send it in the Authorization header as Bearer <key>")
+			return
+		}
+		key := config().Lookup(token)
+		if key == nil {
+			writeError(w, http.StatusUnauthorized, "invalid API key")
+			return
+		}
+		if !key.Allows(cleaned) {
+			log.V(1).Info("Rejected request to endpoint not allowed for key", "key", key.Name, "path", cleaned)
+			writeError(w, http.StatusForbidden, "API key is not allowed to call "+cleaned)
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	})
+}
+
+// writeError responds the way Ollama does, so that its clients report the message.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
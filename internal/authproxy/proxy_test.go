@@ -0,0 +1,65 @@
+package authproxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHandler(t *testing.T) {
+	var upstreamAuth string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	defer upstream.Close()
+	upstreamURL, err := url.Parse(upstream.URL)
+	require.NoError(t, err)
+
+	config := &Config{Keys: []Key{
+		{Name: "admin", SHA256: Hash("admin-key"), Endpoints: []string{"*"}},
+		{Name: "chat", SHA256: Hash("chat-key"), Endpoints: []string{"/api/generate", "/api/chat"}},
+		{Name: "openai", SHA256: Hash("openai-key"), Endpoints: []string{"/v1/*"}},
+	}}
+	proxy := httptest.NewServer(NewHandler(upstreamURL, func() *Config { return config }, logr.Discard()))
+	defer proxy.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		key        string
+		wantStatus int
+	}{
+		{name: "probe without key", method: http.MethodGet, path: "/", wantStatus: http.StatusOK},
+		{name: "missing key", method: http.MethodPost, path: "/api/chat", wantStatus: http.StatusUnauthorized},
+		{name: "unknown key", method: http.MethodPost, path: "/api/chat", key: "foo", wantStatus: http.StatusUnauthorized},
+		{name: "allowed endpoint", method: http.MethodPost, path: "/api/chat", key: "chat-key", wantStatus: http.StatusOK},
+		{name: "endpoint not allowed", method: http.MethodPost, path: "/api/pull", key: "chat-key", wantStatus: http.StatusForbidden},
+		{name: "prefix", method: http.MethodPost, path: "/v1/chat/completions", key: "openai-key", wantStatus: http.StatusOK},
+		{name: "path traversal", method: http.MethodPost, path: "/v1/../api/pull", key: "openai-key", wantStatus: http.StatusForbidden},
+		{name: "root with POST", method: http.MethodPost, path: "/", wantStatus: http.StatusUnauthorized},
+		{name: "admin", method: http.MethodDelete, path: "/api/delete", key: "admin-key", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstreamAuth = ""
+			req, err := http.NewRequest(tt.method, proxy.URL, nil)
+			require.NoError(t, err)
+			// keep the path as is, NewRequest would clean it
+			req.URL.Opaque = tt.path
+			if tt.key != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.key)
+			}
+			resp, err := proxy.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, tt.wantStatus, resp.StatusCode)
+			require.Empty(t, upstreamAuth, "keys are not forwarded to Ollama")
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package model
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/applyconfig"
+	"aerf.io/ollama-operator/internal/authproxy"
+	"aerf.io/ollama-operator/internal/commonmeta"
+	"aerf.io/ollama-operator/internal/defaults"
+	"aerf.io/ollama-operator/internal/ollamaclient"
+
+	"aerf.io/k8sutils"
+)
+
+const (
+	authProxyContainerName = "auth-proxy"
+	authProxyVolumeName    = "auth-proxy-config"
+	authProxyConfigDir     = "/etc/auth-proxy"
+)
+
+// APIKeysSecretName returns the name of the Secret with API keys generated for spec.auth.
+func APIKeysSecretName(model *ollamav1alpha1.Model) string {
+	return model.GetName() + "-api-keys"
+}
+
+// authProxy returns the auth proxy sidecar taking over the Ollama port and its config volume, or nils if spec.auth is not set.
+// Ollama listens only on localhost then, see authProxyUpstreamEnv.
+func authProxy(model *ollamav1alpha1.Model, operator Operator) (*applycorev1.ContainerApplyConfiguration, *applycorev1.VolumeApplyConfiguration) {
+	if model.Spec.Auth == nil {
+		return nil, nil
+	}
+	upstream := &url.URL{Scheme: "http", Host: "127.0.0.1:" + strconv.Itoa(defaults.AuthProxyUpstreamPort)}
+	container := applycorev1.Container().
+		WithName(authProxyContainerName).
+		WithImage(cmp.Or(operator.AuthProxyImage, defaults.AuthProxyImage)).
+		WithImagePullPolicy(corev1.PullIfNotPresent).
+		WithArgs(
+			"--listen=:"+strconv.Itoa(defaults.OllamaPort),
+			"--upstream="+upstream.String(),
+			"--config="+authProxyConfigDir+"/"+authproxy.ConfigFileName,
+		).
+		WithVolumeMounts(applycorev1.VolumeMount().
+			WithName(authProxyVolumeName).
+			WithMountPath(authProxyConfigDir).
+			WithReadOnly(true),
+		)
+	volume := applycorev1.Volume().
+		WithName(authProxyVolumeName).
+		WithSecret(applycorev1.SecretVolumeSource().
+			WithSecretName(APIKeysSecretName(model)).
+			WithItems(applycorev1.KeyToPath().WithKey(authproxy.ConfigFileName).WithPath(authproxy.ConfigFileName)).
+			// the proxy runs as non-root, the config holds only hashes of the keys
+			WithDefaultMode(0o444),
+		)
+	return container, volume
+}
+
+// authProxyUpstreamEnv moves Ollama to the localhost port the auth proxy forwards to.
+func authProxyUpstreamEnv() *applycorev1.EnvVarApplyConfiguration {
+	return applycorev1.EnvVar().WithName("OLLAMA_HOST").WithValue("127.0.0.1:" + strconv.Itoa(defaults.AuthProxyUpstreamPort))
+}
+
+// apiKeysSecret returns the Secret with API keys from spec.auth, the operator's key of the Model and the auth proxy
+// configuration. Keys already present in existing are kept, new ones are generated.
+func apiKeysSecret(model *ollamav1alpha1.Model, existing *corev1.Secret) (*applycorev1.SecretApplyConfiguration, error) {
+	data := map[string][]byte{}
+	config := authproxy.Config{}
+	keyFor := func(name string, endpoints []string) {
+		token := string(existing.Data[name])
+		if token == "" {
+			token = rand.Text()
+		}
+		data[name] = []byte(token)
+		config.Keys = append(config.Keys, authproxy.Key{Name: name, SHA256: authproxy.Hash(token), Endpoints: endpoints})
+	}
+	keyFor(authproxy.OperatorKeyName, []string{"*"})
+	for _, key := range model.Spec.Auth.Keys {
+		keyFor(key.Name, key.Endpoints)
+	}
+	configData, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	data[authproxy.ConfigFileName] = configData
+
+	return applycorev1.Secret(APIKeysSecretName(model), model.GetNamespace()).
+		WithLabels(commonmeta.LabelsForResource(model.GetName(), map[string]string{modelLabelKey: model.GetName()})).
+		WithOwnerReferences(applyconfig.ControllerReferenceFrom(model)).
+		WithType(corev1.SecretTypeOpaque).
+		WithData(data), nil
+}
+
+// applyAPIKeys applies the Secret with API keys from spec.auth, or deletes it once spec.auth is removed.
+// Secrets are not cached, see cmd/operator, so the existing one is read from the API server.
+func (r *Reconciler) applyAPIKeys(ctx context.Context, model *ollamav1alpha1.Model) error {
+	if model.Spec.Auth == nil && model.Status.Auth == nil {
+		return nil
+	}
+	existing := &corev1.Secret{}
+	if err := r.apiReader.Get(ctx, client.ObjectKey{Namespace: model.GetNamespace(), Name: APIKeysSecretName(model)}, existing); client.IgnoreNotFound(err) != nil {
+		return errors.Wrap(err, "failed to fetch api keys secret")
+	}
+	controlled := existing.GetUID() == "" || metav1.IsControlledBy(existing, model)
+	if model.Spec.Auth == nil {
+		if existing.GetUID() != "" && controlled {
+			if err := r.client.Delete(ctx, existing); client.IgnoreNotFound(err) != nil {
+				return errors.Wrap(err, "failed to delete api keys secret")
+			}
+		}
+		model.Status.Auth = nil
+		r.adminKeys.Delete(client.ObjectKeyFromObject(model))
+		return nil
+	}
+	if !controlled {
+		return fmt.Errorf("secret %s already exists and is not controlled by the Model", existing.GetName())
+	}
+
+	secret, err := apiKeysSecret(model, existing)
+	if err != nil {
+		return fmt.Errorf("while creating api keys secret: %s", err)
+	}
+	res, err := k8sutils.ToUnstructured(secret)
+	if err != nil {
+		return err
+	}
+	// the object isn't logged, it contains the keys
+	ctrl.LoggerFrom(ctx).V(1).Info("Applying object", "kind", res.GetKind(), "name", res.GetName())
+	if err := r.apply(ctx, res); err != nil {
+		return fmt.Errorf("while applying Secret %s: %s", res.GetName(), err)
+	}
+	model.Status.Auth = &ollamav1alpha1.AuthStatus{SecretName: res.GetName()}
+	r.adminKeys.Store(client.ObjectKeyFromObject(model), string(secret.Data[authproxy.OperatorKeyName]))
+	return nil
+}
+
+// loadAdminKey reads the operator's key of the Model from its api keys Secret, unless applyAPIKeys already stored it,
+// e.g. when the Model is deleted right after the operator restarted.
+func (r *Reconciler) loadAdminKey(ctx context.Context, model *ollamav1alpha1.Model) error {
+	if model.Status.Auth == nil {
+		return nil
+	}
+	if _, ok := r.adminKeys.Load(client.ObjectKeyFromObject(model)); ok {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.apiReader.Get(ctx, client.ObjectKey{Namespace: model.GetNamespace(), Name: model.Status.Auth.SecretName}, secret); err != nil {
+		return errors.Wrap(client.IgnoreNotFound(err), "failed to fetch api keys secret")
+	}
+	r.adminKeys.Store(client.ObjectKeyFromObject(model), string(secret.Data[authproxy.OperatorKeyName]))
+	return nil
+}
+
+// ollamaClientFor returns the client talking to the pod of the Model, authenticated with the operator's key of the Model
+// if it has spec.auth. Models without spec.auth and OllamaServers never get any key.
+func (r *Reconciler) ollamaClientFor(model *ollamav1alpha1.Model, pod *corev1.Pod) ollamaclient.Interface {
+	adminKey, _ := r.adminKeys.Load(client.ObjectKeyFromObject(model))
+	apiKey, _ := adminKey.(string)
+	return r.ollamaClientProvider.ForPod(pod, apiKey)
+}
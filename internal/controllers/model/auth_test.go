@@ -0,0 +1,86 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/authproxy"
+)
+
+func TestResources_auth(t *testing.T) {
+	model := &ollamav1alpha1.Model{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ollamav1alpha1.ModelGroupVersionKind.GroupVersion().String(), Kind: ollamav1alpha1.ModelKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default", UID: "uid"},
+		Spec: ollamav1alpha1.ModelSpec{
+			Model: "phi3",
+			Auth:  &ollamav1alpha1.Auth{Keys: []ollamav1alpha1.APIKey{{Name: "chat", Endpoints: []string{"/api/chat"}}}},
+		},
+	}
+	sts := statefulSetOf(t, model, Operator{AuthProxyImage: "ghcr.io/aerfio/ollama-operator/auth-proxy:sha-1234567"})
+	containers := sts.Spec.Template.Spec.Containers
+	require.Len(t, containers, 2)
+	require.Contains(t, containers[0].Env, corev1.EnvVar{Name: "OLLAMA_HOST", Value: "127.0.0.1:11433"})
+	require.Equal(t, authProxyContainerName, containers[1].Name)
+	require.Equal(t, "ghcr.io/aerfio/ollama-operator/auth-proxy:sha-1234567", containers[1].Image)
+	require.Contains(t, containers[1].Args, "--listen=:11434")
+	require.Contains(t, sts.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: authProxyVolumeName,
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+			SecretName:  "phi3-api-keys",
+			Items:       []corev1.KeyToPath{{Key: authproxy.ConfigFileName, Path: authproxy.ConfigFileName}},
+			DefaultMode: ptr.To(int32(0o444)),
+		}},
+	})
+}
+
+func Test_apiKeysSecret(t *testing.T) {
+	model := &ollamav1alpha1.Model{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ollamav1alpha1.ModelGroupVersionKind.GroupVersion().String(), Kind: ollamav1alpha1.ModelKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default", UID: "uid"},
+		Spec: ollamav1alpha1.ModelSpec{
+			Model: "phi3",
+			Auth: &ollamav1alpha1.Auth{Keys: []ollamav1alpha1.APIKey{
+				{Name: "chat", Endpoints: []string{"/api/generate", "/api/chat"}},
+				{Name: "openai", Endpoints: []string{"/v1/*"}},
+			}},
+		},
+	}
+	existing := &corev1.Secret{Data: map[string][]byte{
+		authproxy.OperatorKeyName: []byte("admin-key"),
+		"chat":                    []byte("existing-key"),
+		"removed":                 []byte("removed-key"),
+	}}
+
+	secret, err := apiKeysSecret(model, existing)
+	require.NoError(t, err)
+	require.Equal(t, "phi3-api-keys", *secret.Name)
+	require.Equal(t, ollamav1alpha1.ModelKind, *secret.OwnerReferences[0].Kind)
+	require.Equal(t, "admin-key", string(secret.Data[authproxy.OperatorKeyName]), "the operator's key is kept")
+	require.Equal(t, "existing-key", string(secret.Data["chat"]), "existing keys are kept")
+	require.NotEmpty(t, secret.Data["openai"])
+	require.NotContains(t, secret.Data, "removed")
+
+	config, err := authproxy.ParseConfig(secret.Data[authproxy.ConfigFileName])
+	require.NoError(t, err)
+	require.NotContains(t, string(secret.Data[authproxy.ConfigFileName]), "admin-key", "keys are stored hashed")
+	admin := config.Lookup("admin-key")
+	require.NotNil(t, admin)
+	require.True(t, admin.Allows("/api/pull"))
+	chat := config.Lookup("existing-key")
+	require.NotNil(t, chat)
+	require.True(t, chat.Allows("/api/chat"))
+	require.False(t, chat.Allows("/api/pull"))
+	require.Nil(t, config.Lookup("removed-key"))
+	openai := config.Lookup(string(secret.Data["openai"]))
+	require.NotNil(t, openai)
+	require.True(t, openai.Allows("/v1/chat/completions"))
+
+	secret, err = apiKeysSecret(model, &corev1.Secret{})
+	require.NoError(t, err)
+	require.NotEmpty(t, secret.Data[authproxy.OperatorKeyName], "the operator's key is generated for each Model")
+}
@@ -0,0 +1,319 @@
+package model
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	ollamaapi "github.com/ollama/ollama/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/applyconfig"
+	"aerf.io/ollama-operator/internal/commonmeta"
+	"aerf.io/ollama-operator/internal/defaults"
+	"aerf.io/ollama-operator/internal/ollamaclient"
+
+	"aerf.io/k8sutils"
+)
+
+const (
+	blobServerContainerName = "blob-server"
+	// PVCs with imported files are mounted under this directory of the blob-server container, each in a subdirectory named after the PVC
+	importsDir = "/imports"
+
+	blobServerConfigVolumeName = "blob-server-config"
+	blobServerConfigDir        = "/etc/blob-server"
+	// blobServerConfigKey is the key of the httpd config in the blob-server Secret, it requires basic auth for every path
+	blobServerConfigKey = "httpd.conf"
+	blobServerTokenKey  = "token"
+	blobServerUser      = "ollama-operator"
+)
+
+func blobServerSecretName(model *ollamav1alpha1.Model) string {
+	return model.GetName() + "-blob-server"
+}
+
+// modelBlob is a file uploaded to Ollama with the blob API before the model is created.
+type modelBlob struct {
+	name   string
+	digest string
+	// data of files from ConfigMaps and Secrets
+	data []byte
+	// file on a PVC, served by the blob-server sidecar
+	claimName, path string
+}
+
+// modelBlobs reads files from ConfigMaps and Secrets and verifies their digests. Files from PVCs are only verified by Ollama during the upload.
+func (r *Reconciler) modelBlobs(ctx context.Context, model *ollamav1alpha1.Model, files []ollamav1alpha1.ModelFile) ([]modelBlob, error) {
+	blobs := make([]modelBlob, 0, len(files))
+	for _, file := range files {
+		blob := modelBlob{name: file.Name, digest: file.Digest}
+		switch {
+		case file.ConfigMapKeyRef != nil:
+			ref := file.ConfigMapKeyRef
+			cm := &corev1.ConfigMap{}
+			if err := r.apiReader.Get(ctx, client.ObjectKey{Namespace: model.GetNamespace(), Name: ref.Name}, cm); err != nil {
+				return nil, errors.Wrapf(err, "failed to fetch ConfigMap %s", ref.Name)
+			}
+			if data, ok := cm.BinaryData[ref.Key]; ok {
+				blob.data = data
+			} else if data, ok := cm.Data[ref.Key]; ok {
+				blob.data = []byte(data)
+			} else {
+				return nil, fmt.Errorf("key %q not found in ConfigMap %s", ref.Key, ref.Name)
+			}
+		case file.SecretKeyRef != nil:
+			ref := file.SecretKeyRef
+			secret := &corev1.Secret{}
+			if err := r.apiReader.Get(ctx, client.ObjectKey{Namespace: model.GetNamespace(), Name: ref.Name}, secret); err != nil {
+				return nil, errors.Wrapf(err, "failed to fetch Secret %s", ref.Name)
+			}
+			data, ok := secret.Data[ref.Key]
+			if !ok {
+				return nil, fmt.Errorf("key %q not found in Secret %s", ref.Key, ref.Name)
+			}
+			blob.data = data
+		case file.PersistentVolumeClaim != nil:
+			blob.claimName = file.PersistentVolumeClaim.ClaimName
+			blob.path = file.PersistentVolumeClaim.Path
+			blobs = append(blobs, blob)
+			continue
+		}
+
+		digest := fmt.Sprintf("sha256:%x", sha256.Sum256(blob.data))
+		if blob.digest != "" && blob.digest != digest {
+			return nil, fmt.Errorf("digest mismatch of %q file, expected %s, got %s", file.Name, blob.digest, digest)
+		}
+		blob.digest = digest
+		blobs = append(blobs, blob)
+	}
+	return blobs, nil
+}
+
+// blobDigests maps names of the blobs to their digests, as expected by the create API.
+func blobDigests(blobs []modelBlob) map[string]string {
+	if len(blobs) == 0 {
+		return nil
+	}
+	digests := make(map[string]string, len(blobs))
+	for _, blob := range blobs {
+		digests[blob.name] = blob.digest
+	}
+	return digests
+}
+
+// blobOpener opens the content of blobs, files from PVCs are downloaded from the blob-server sidecar of given pod.
+type blobOpener func(ctx context.Context, blob modelBlob) (io.ReadCloser, int64, error)
+
+func (r *Reconciler) blobOpenerFor(model *ollamav1alpha1.Model, pod *corev1.Pod) blobOpener {
+	storedToken, _ := r.blobServerTokens.Load(client.ObjectKeyFromObject(model))
+	token, _ := storedToken.(string)
+	return func(ctx context.Context, blob modelBlob) (io.ReadCloser, int64, error) {
+		if blob.claimName == "" {
+			return io.NopCloser(bytes.NewReader(blob.data)), int64(len(blob.data)), nil
+		}
+		u := url.URL{
+			Scheme: "http",
+			Host:   pod.Status.PodIP + ":" + strconv.Itoa(defaults.BlobServerPort),
+			Path:   path.Join("/", blob.claimName, blob.path),
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, 0, err
+		}
+		req.SetBasicAuth(blobServerUser, token)
+		resp, err := r.baseHTTPClient.Do(req)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to download %s from the blob server", u.Path)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, 0, errors.Join(fmt.Errorf("failed to download %s from the blob server: %s", u.Path, resp.Status), resp.Body.Close())
+		}
+		return resp.Body, resp.ContentLength, nil
+	}
+}
+
+// uploadBlobs uploads the blobs with the blob API, reporting the progress the same way Ollama reports layers of a pull.
+func uploadBlobs(ctx context.Context, ollamaCli ollamaclient.Interface, open blobOpener, blobs []modelBlob, progressFunc ollamaapi.PullProgressFunc) error {
+	for _, blob := range blobs {
+		body, size, err := open(ctx, blob)
+		if err != nil {
+			return err
+		}
+		status := fmt.Sprintf("uploading %s", blob.name)
+		if err := progressFunc(ollamaapi.ProgressResponse{Status: status, Digest: blob.digest, Total: size}); err != nil {
+			return errors.Join(err, body.Close())
+		}
+		err = ollamaCli.CreateBlob(ctx, blob.digest, &progressReader{
+			r: body,
+			report: func(completed int64) error {
+				return progressFunc(ollamaapi.ProgressResponse{Status: status, Digest: blob.digest, Total: size, Completed: completed})
+			},
+		})
+		if err := errors.Join(err, body.Close()); err != nil {
+			return errors.Wrapf(err, "failed to upload %q file", blob.name)
+		}
+	}
+	return nil
+}
+
+// progressReader reports the number of bytes read so far after every read.
+type progressReader struct {
+	r         io.Reader
+	completed int64
+	report    func(completed int64) error
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.completed += int64(n)
+	if reportErr := p.report(p.completed); reportErr != nil {
+		return n, reportErr
+	}
+	return n, err
+}
+
+// importedClaims returns the sorted names of the PVCs with files imported by the desired models.
+func importedClaims(model *ollamav1alpha1.Model) []string {
+	var claims []string
+	for _, entry := range model.DesiredModels() {
+		if entry.Create == nil {
+			continue
+		}
+		for _, file := range slices.Concat(entry.Create.Files, entry.Create.Adapters) {
+			if file.PersistentVolumeClaim != nil && !slices.Contains(claims, file.PersistentVolumeClaim.ClaimName) {
+				claims = append(claims, file.PersistentVolumeClaim.ClaimName)
+			}
+		}
+	}
+	slices.Sort(claims)
+	return claims
+}
+
+// blobServer returns the sidecar serving files from PVCs to the operator and its volumes, or nils if no file is imported from a PVC.
+// The port is reachable from other pods unless spec.networkPolicy is set, so the sidecar requires basic auth with the token
+// from the Secret applied by applyBlobServerSecret.
+func blobServer(model *ollamav1alpha1.Model) (*applycorev1.ContainerApplyConfiguration, []*applycorev1.VolumeApplyConfiguration) {
+	claims := importedClaims(model)
+	if len(claims) == 0 {
+		return nil, nil
+	}
+
+	container := applycorev1.Container().
+		WithName(blobServerContainerName).
+		WithImage(defaults.BlobServerImage).
+		WithImagePullPolicy(corev1.PullIfNotPresent).
+		WithCommand("httpd", "-f", "-p", strconv.Itoa(defaults.BlobServerPort), "-h", importsDir, "-c", path.Join(blobServerConfigDir, blobServerConfigKey)).
+		WithPorts(
+			applycorev1.ContainerPort().
+				WithName("http-blobs").
+				WithContainerPort(defaults.BlobServerPort).
+				WithProtocol(corev1.ProtocolTCP),
+		).
+		WithReadinessProbe(
+			applycorev1.Probe().
+				WithPeriodSeconds(5).
+				WithTCPSocket(applycorev1.TCPSocketAction().WithPort(intstr.FromString("http-blobs"))),
+		).
+		WithVolumeMounts(applycorev1.VolumeMount().
+			WithName(blobServerConfigVolumeName).
+			WithMountPath(blobServerConfigDir).
+			WithReadOnly(true),
+		)
+	volumes := make([]*applycorev1.VolumeApplyConfiguration, 0, len(claims)+1)
+	volumes = append(volumes, applycorev1.Volume().
+		WithName(blobServerConfigVolumeName).
+		WithSecret(applycorev1.SecretVolumeSource().
+			WithSecretName(blobServerSecretName(model)).
+			WithItems(applycorev1.KeyToPath().WithKey(blobServerConfigKey).WithPath(blobServerConfigKey)),
+		),
+	)
+	for i, claim := range claims {
+		// PVC names can be longer than volume names
+		volumeName := fmt.Sprintf("import-%d", i)
+		volumes = append(volumes, applycorev1.Volume().
+			WithName(volumeName).
+			WithPersistentVolumeClaim(applycorev1.PersistentVolumeClaimVolumeSource().
+				WithClaimName(claim).
+				WithReadOnly(true),
+			),
+		)
+		container.WithVolumeMounts(applycorev1.VolumeMount().
+			WithName(volumeName).
+			WithMountPath(path.Join(importsDir, claim)).
+			WithReadOnly(true),
+		)
+	}
+	return container, volumes
+}
+
+// blobServerSecret returns the Secret with the token of the blob-server sidecar and the httpd config requiring it.
+func blobServerSecret(model *ollamav1alpha1.Model, token string) *applycorev1.SecretApplyConfiguration {
+	return applycorev1.Secret(blobServerSecretName(model), model.GetNamespace()).
+		WithLabels(commonmeta.LabelsForResource(model.GetName(), map[string]string{modelLabelKey: model.GetName()})).
+		WithOwnerReferences(applyconfig.ControllerReferenceFrom(model)).
+		WithType(corev1.SecretTypeOpaque).
+		WithData(map[string][]byte{
+			blobServerTokenKey:  []byte(token),
+			blobServerConfigKey: fmt.Appendf(nil, "/:%s:%s\n", blobServerUser, token),
+		})
+}
+
+// applyBlobServerSecret applies the Secret with the credentials of the blob-server sidecar, or deletes it once no file
+// is imported from a PVC anymore. The existing token is read from the API server only if it's not known yet, e.g. after
+// the operator restarted, Secrets are not cached, see cmd/operator.
+func (r *Reconciler) applyBlobServerSecret(ctx context.Context, model *ollamav1alpha1.Model) error {
+	key := client.ObjectKeyFromObject(model)
+	storedToken, known := r.blobServerTokens.Load(key)
+	if len(importedClaims(model)) == 0 {
+		if !known {
+			// the Secret of a blob server removed before a restart of the operator is left to the garbage collector
+			return nil
+		}
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: model.GetNamespace(), Name: blobServerSecretName(model)}}
+		if err := r.client.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return errors.Wrap(err, "failed to delete blob server secret")
+		}
+		r.blobServerTokens.Delete(key)
+		return nil
+	}
+
+	token, _ := storedToken.(string)
+	if token == "" {
+		existing := &corev1.Secret{}
+		if err := r.apiReader.Get(ctx, client.ObjectKey{Namespace: model.GetNamespace(), Name: blobServerSecretName(model)}, existing); client.IgnoreNotFound(err) != nil {
+			return errors.Wrap(err, "failed to fetch blob server secret")
+		}
+		if existing.GetUID() != "" && !metav1.IsControlledBy(existing, model) {
+			return fmt.Errorf("secret %s already exists and is not controlled by the Model", existing.GetName())
+		}
+		token = cmp.Or(string(existing.Data[blobServerTokenKey]), rand.Text())
+	}
+	res, err := k8sutils.ToUnstructured(blobServerSecret(model, token))
+	if err != nil {
+		return err
+	}
+	// the object isn't logged, it contains the token
+	ctrl.LoggerFrom(ctx).V(1).Info("Applying object", "kind", res.GetKind(), "name", res.GetName())
+	if err := r.apply(ctx, res); err != nil {
+		return fmt.Errorf("while applying Secret %s: %s", res.GetName(), err)
+	}
+	r.blobServerTokens.Store(key, token)
+	return nil
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/ollama/ollama/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/ollamaclient"
+)
+
+func Test_modelBlobs(t *testing.T) {
+	adapter := []byte("lora adapter")
+	adapterDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(adapter))
+	r := &Reconciler{apiReader: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "adapters", Namespace: "default"},
+			BinaryData: map[string][]byte{"lora.gguf": adapter},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "adapters", Namespace: "default"},
+			Data:       map[string][]byte{"lora.gguf": adapter},
+		},
+	).Build()}
+	model := &ollamav1alpha1.Model{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+
+	blobs, err := r.modelBlobs(context.Background(), model, []ollamav1alpha1.ModelFile{
+		{
+			Name:            "cm.gguf",
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "adapters"}, Key: "lora.gguf"},
+		},
+		{
+			Name:         "secret.gguf",
+			Digest:       adapterDigest,
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "adapters"}, Key: "lora.gguf"},
+		},
+		{
+			Name:                  "pvc.gguf",
+			Digest:                "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			PersistentVolumeClaim: &ollamav1alpha1.PersistentVolumeClaimFile{ClaimName: "weights", Path: "adapters/lora.gguf"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"cm.gguf":     adapterDigest,
+		"secret.gguf": adapterDigest,
+		"pvc.gguf":    "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+	}, blobDigests(blobs))
+
+	_, err = r.modelBlobs(context.Background(), model, []ollamav1alpha1.ModelFile{{
+		Name:            "cm.gguf",
+		Digest:          "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+		ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "adapters"}, Key: "lora.gguf"},
+	}})
+	require.ErrorContains(t, err, "digest mismatch")
+}
+
+func Test_uploadBlobs(t *testing.T) {
+	r := &Reconciler{}
+	blobs := []modelBlob{
+		{name: "model.gguf", digest: "sha256:model", data: []byte("weights")},
+		{name: "adapter.gguf", digest: "sha256:adapter", data: []byte("lora")},
+	}
+	uploaded := map[string]string{}
+	cli := &ollamaclient.TestOllamaClient{
+		OnCreateBlob: func(ctx context.Context, digest string, r io.Reader) error {
+			data, err := io.ReadAll(r)
+			uploaded[digest] = string(data)
+			return err
+		},
+	}
+
+	tracker := newPullProgressTracker("custom", "test-0", metav1.Now().Time)
+	err := uploadBlobs(context.Background(), cli, r.blobOpenerFor(&ollamav1alpha1.Model{}, &corev1.Pod{}), blobs, func(resp api.ProgressResponse) error {
+		tracker.observe(resp, metav1.Now().Time)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"sha256:model": "weights", "sha256:adapter": "lora"}, uploaded)
+	require.Equal(t, int64(len("weights")+len("lora")), tracker.progress.TotalBytes)
+	require.Equal(t, tracker.progress.TotalBytes, tracker.progress.CompletedBytes)
+	require.Equal(t, int32(100), tracker.progress.Percent)
+}
+
+func TestReconciler_applyBlobServerSecret(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	newReconciler := func() *Reconciler {
+		return &Reconciler{client: client.WithFieldOwner(cli, "ollama-operator"), apiReader: cli}
+	}
+	model := &ollamav1alpha1.Model{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ollamav1alpha1.ModelGroupVersionKind.GroupVersion().String(), Kind: ollamav1alpha1.ModelKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid"},
+		Spec: ollamav1alpha1.ModelSpec{Model: "test", Models: []ollamav1alpha1.ModelEntry{{
+			Name: "custom",
+			Create: &ollamav1alpha1.ModelCreate{Files: []ollamav1alpha1.ModelFile{{
+				Name:                  "model.gguf",
+				PersistentVolumeClaim: &ollamav1alpha1.PersistentVolumeClaimFile{ClaimName: "weights", Path: "model.gguf"},
+			}}},
+		}}},
+	}
+	ctx := context.Background()
+	secretKey := client.ObjectKey{Namespace: "default", Name: "test-blob-server"}
+
+	r := newReconciler()
+	require.NoError(t, r.applyBlobServerSecret(ctx, model))
+	secret := &corev1.Secret{}
+	require.NoError(t, cli.Get(ctx, secretKey, secret))
+	token := string(secret.Data[blobServerTokenKey])
+	require.NotEmpty(t, token)
+	require.Equal(t, "/:ollama-operator:"+token+"\n", string(secret.Data[blobServerConfigKey]))
+
+	restarted := newReconciler()
+	require.NoError(t, restarted.applyBlobServerSecret(ctx, model))
+	storedToken, _ := restarted.blobServerTokens.Load(client.ObjectKeyFromObject(model))
+	require.Equal(t, token, storedToken, "kept after a restart of the operator")
+
+	model.Spec.Models = nil
+	require.NoError(t, restarted.applyBlobServerSecret(ctx, model))
+	require.True(t, apierrors.IsNotFound(cli.Get(ctx, secretKey, &corev1.Secret{})))
+}
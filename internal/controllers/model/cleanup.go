@@ -0,0 +1,132 @@
+package model
+
+import (
+	"context"
+	"slices"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	ollamaapi "github.com/ollama/ollama/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/kubectl/pkg/util/podutils"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/ollamaclient"
+)
+
+// ensureFinalizer adds or removes the cleanup finalizer depending on spec.cleanupOnDelete, and the volumes finalizer
+// depending on spec.storage.retentionPolicy.
+func (r *Reconciler) ensureFinalizer(ctx context.Context, model *ollamav1alpha1.Model) error {
+	// patch a copy so the server response doesn't overwrite the in-memory object
+	patched := model.DeepCopy()
+	setFinalizer(patched, ollamav1alpha1.ModelCleanupFinalizer, model.Spec.CleanupOnDelete)
+	setFinalizer(patched, ollamav1alpha1.ModelVolumesFinalizer, retentionPolicy(model) == ollamav1alpha1.PVCRetentionPolicyDelete)
+	if slices.Equal(patched.GetFinalizers(), model.GetFinalizers()) {
+		return nil
+	}
+
+	if err := r.client.Patch(ctx, patched, client.MergeFromWithOptions(model, client.MergeFromWithOptimisticLock{})); err != nil {
+		return errors.Wrap(err, "failed to update finalizers")
+	}
+	model.SetFinalizers(patched.GetFinalizers())
+	model.SetResourceVersion(patched.GetResourceVersion())
+	return nil
+}
+
+func setFinalizer(model *ollamav1alpha1.Model, finalizer string, set bool) {
+	if set {
+		controllerutil.AddFinalizer(model, finalizer)
+	} else {
+		controllerutil.RemoveFinalizer(model, finalizer)
+	}
+}
+
+// finalize deletes all models pulled by the operator and the PVCs, depending on the finalizers set by ensureFinalizer,
+// and removes the finalizers.
+func (r *Reconciler) finalize(ctx context.Context, model *ollamav1alpha1.Model) error {
+	patch := client.MergeFromWithOptions(model.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	finalizers := len(model.GetFinalizers())
+	if controllerutil.ContainsFinalizer(model, ollamav1alpha1.ModelCleanupFinalizer) {
+		if err := r.loadAdminKey(ctx, model); err != nil {
+			return err
+		}
+		if err := r.cleanupModels(ctx, model); err != nil {
+			return err
+		}
+		controllerutil.RemoveFinalizer(model, ollamav1alpha1.ModelCleanupFinalizer)
+	}
+	if controllerutil.ContainsFinalizer(model, ollamav1alpha1.ModelVolumesFinalizer) {
+		if err := r.deleteVolumes(ctx, model); err != nil {
+			return err
+		}
+		controllerutil.RemoveFinalizer(model, ollamav1alpha1.ModelVolumesFinalizer)
+	}
+	if len(model.GetFinalizers()) == finalizers {
+		return nil
+	}
+	r.adminKeys.Delete(client.ObjectKeyFromObject(model))
+	r.creates.Delete(client.ObjectKeyFromObject(model))
+	r.blobServerTokens.Delete(client.ObjectKeyFromObject(model))
+	return errors.Wrap(r.client.Patch(ctx, model, patch), "failed to remove finalizers")
+}
+
+// cleanupModels deletes all models pulled by the operator.
+func (r *Reconciler) cleanupModels(ctx context.Context, model *ollamav1alpha1.Model) error {
+	pods, err := r.listOllamaPods(ctx, model)
+	if err != nil {
+		return err
+	}
+	pods = slices.DeleteFunc(pods, func(pod corev1.Pod) bool {
+		return !podutils.IsPodReady(&pod)
+	})
+	if len(pods) == 0 {
+		// nothing is able to serve the delete requests, there's no point in blocking the deletion
+		ctrl.LoggerFrom(ctx).Info("no ready Ollama pods, skipping models cleanup")
+		r.eventRecorderFor(model).WarningEvent("CleaningUpModels", "CleaningUpModels", "No ready Ollama pods, models were not deleted")
+		return nil
+	}
+	return r.deleteModels(ctx, model, pods, model.Status.PulledModels)
+}
+
+// deleteStaleModels deletes models pulled by the operator that are no longer desired.
+func (r *Reconciler) deleteStaleModels(ctx context.Context, model *ollamav1alpha1.Model, pods []corev1.Pod, desired []ollamav1alpha1.ModelEntry) error {
+	var stale []string
+	for _, name := range model.Status.PulledModels {
+		if !slices.ContainsFunc(desired, func(e ollamav1alpha1.ModelEntry) bool { return e.Name == name }) {
+			stale = append(stale, name)
+		}
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	return r.deleteModels(ctx, model, pods, stale)
+}
+
+// deleteModels deletes given models from every pod and drops them from status.pulledModels. Models used by other Models
+// hosted on the same OllamaServer are only dropped from the status.
+func (r *Reconciler) deleteModels(ctx context.Context, model *ollamav1alpha1.Model, pods []corev1.Pod, models []string) error {
+	log := ctrl.LoggerFrom(ctx)
+	shared, err := r.modelsOnServer(ctx, model)
+	if err != nil {
+		return err
+	}
+	for _, name := range slices.Clone(models) {
+		if slices.ContainsFunc(shared, func(other string) bool { return sameModel(other, name) }) {
+			log.V(1).Info("keeping model used by other Models on the OllamaServer", "model", name)
+			model.Status.PulledModels = slices.DeleteFunc(model.Status.PulledModels, func(pulled string) bool { return pulled == name })
+			continue
+		}
+		for i := range pods {
+			err := r.ollamaClientFor(model, &pods[i]).Delete(ctx, &ollamaapi.DeleteRequest{Model: name})
+			if err != nil && !ollamaclient.IsNotFound(err) {
+				return errors.Wrapf(err, "failed to delete %q model from pod %q", name, pods[i].GetName())
+			}
+		}
+		log.V(1).Info("deleted model", "model", name)
+		r.eventRecorderFor(model).NormalEventf("DeletingModel", "DeletedModel", "Deleted %q model", name)
+		model.Status.PulledModels = slices.DeleteFunc(model.Status.PulledModels, func(pulled string) bool { return pulled == name })
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ollama/ollama/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/ollamaclient"
+)
+
+func TestReconciler_deleteStaleModels(t *testing.T) {
+	var deleted []string
+	r := &Reconciler{
+		recorder: record.NewEventRecorderAdapter(record.NewFakeRecorder(100)),
+		ollamaClientProvider: &ollamaclient.TestOllamaClientProvider{
+			Client: &ollamaclient.TestOllamaClient{
+				OnDelete: func(ctx context.Context, req *api.DeleteRequest) error {
+					deleted = append(deleted, req.Model)
+					if req.Model == "gone" {
+						return api.StatusError{StatusCode: http.StatusNotFound, ErrorMessage: "model 'gone' not found"}
+					}
+					return nil
+				},
+			},
+		},
+	}
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       ollamav1alpha1.ModelSpec{Model: "phi3"},
+		Status: ollamav1alpha1.ModelStatus{
+			PulledModels: []string{"phi3", "llama3.1", "gone"},
+		},
+	}
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-1"}},
+	}
+
+	err := r.deleteStaleModels(context.Background(), model, pods, model.DesiredModels())
+	require.NoError(t, err)
+	require.Equal(t, []string{"llama3.1", "llama3.1", "gone", "gone"}, deleted, "stale models must be deleted from every pod")
+	require.Equal(t, []string{"phi3"}, model.Status.PulledModels)
+}
+
+func TestReconciler_deleteStaleModels_sharedOnServer(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(s))
+	require.NoError(t, ollamav1alpha1.AddToScheme(s))
+	serverRef := &corev1.LocalObjectReference{Name: "shared"}
+	other := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+		Spec:       ollamav1alpha1.ModelSpec{ServerRef: serverRef, Model: "llama3.1"},
+	}
+	var deleted []string
+	r := &Reconciler{
+		client:   fake.NewClientBuilder().WithScheme(s).WithObjects(other).Build(),
+		recorder: record.NewEventRecorderAdapter(record.NewFakeRecorder(100)),
+		ollamaClientProvider: &ollamaclient.TestOllamaClientProvider{
+			Client: &ollamaclient.TestOllamaClient{
+				OnDelete: func(ctx context.Context, req *api.DeleteRequest) error {
+					deleted = append(deleted, req.Model)
+					return nil
+				},
+			},
+		},
+	}
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       ollamav1alpha1.ModelSpec{ServerRef: serverRef, Model: "phi3"},
+		Status: ollamav1alpha1.ModelStatus{
+			PulledModels: []string{"phi3", "llama3.1:latest", "gemma2"},
+		},
+	}
+	pods := []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "shared-0"}}}
+
+	err := r.deleteStaleModels(context.Background(), model, pods, model.DesiredModels())
+	require.NoError(t, err)
+	require.Equal(t, []string{"gemma2"}, deleted, "llama3.1:latest is the llama3.1 model used by the other Model")
+	require.Equal(t, []string{"phi3"}, model.Status.PulledModels)
+}
@@ -0,0 +1,219 @@
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	ollamaapi "github.com/ollama/ollama/api"
+	"github.com/ollama/ollama/parser"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+// modelfileResyncPeriod is how often Modelfiles and files of created models are read again from ConfigMaps and Secrets.
+// They aren't cached as they don't have to be labeled, so their changes are not watched.
+const modelfileResyncPeriod = time.Minute
+
+// modelCreate is the create request of a model built from a Modelfile.
+type modelCreate struct {
+	req *ollamaapi.CreateRequest
+	// files and adapters uploaded before the model is created
+	blobs []modelBlob
+	// hash of the request, the model is re-created when it changes
+	hash string
+}
+
+// readCreates are the create requests of a Model read at its generation, see createRequests.
+type readCreates struct {
+	// uid tells apart a Model re-created under the same name, its generation starts again at 1
+	uid        types.UID
+	generation int64
+	readAt     time.Time
+	creates    map[string]*modelCreate
+}
+
+// createRequests builds create requests of all desired models that are built from a Modelfile, keyed by model name.
+// They're reused until the spec changes or modelfileResyncPeriod passes, so the referenced ConfigMaps and Secrets
+// are not read from the API server on every requeue.
+func (r *Reconciler) createRequests(ctx context.Context, model *ollamav1alpha1.Model, desired []ollamav1alpha1.ModelEntry) (map[string]*modelCreate, error) {
+	key := client.ObjectKeyFromObject(model)
+	if read, ok := r.creates.Load(key); ok {
+		if read := read.(readCreates); read.uid == model.GetUID() && read.generation == model.GetGeneration() && r.timeNowFn().Sub(read.readAt) < modelfileResyncPeriod {
+			return read.creates, nil
+		}
+	}
+
+	creates := map[string]*modelCreate{}
+	for _, entry := range desired {
+		if entry.Create == nil {
+			continue
+		}
+		cmds, err := r.modelfileCommands(ctx, model, entry.Create)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read Modelfile of %q model", entry.Name)
+		}
+		files, err := r.modelBlobs(ctx, model, entry.Create.Files)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read files of %q model", entry.Name)
+		}
+		adapters, err := r.modelBlobs(ctx, model, entry.Create.Adapters)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read adapters of %q model", entry.Name)
+		}
+		req, err := createRequest(entry.Name, cmds, blobDigests(files), blobDigests(adapters))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid Modelfile of %q model", entry.Name)
+		}
+		hash, err := createHash(req)
+		if err != nil {
+			return nil, err
+		}
+		creates[entry.Name] = &modelCreate{req: req, blobs: slices.Concat(files, adapters), hash: hash}
+	}
+	r.creates.Store(key, readCreates{uid: model.GetUID(), generation: model.GetGeneration(), readAt: r.timeNowFn(), creates: creates})
+	return creates, nil
+}
+
+// modelfileCommands returns commands of the Modelfile from the ConfigMap, or of the one set inline in spec.
+func (r *Reconciler) modelfileCommands(ctx context.Context, model *ollamav1alpha1.Model, create *ollamav1alpha1.ModelCreate) ([]parser.Command, error) {
+	if ref := create.ModelfileRef; ref != nil {
+		cm := &corev1.ConfigMap{}
+		if err := r.apiReader.Get(ctx, client.ObjectKey{Namespace: model.GetNamespace(), Name: ref.Name}, cm); err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch ConfigMap %s", ref.Name)
+		}
+		data, ok := cm.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %q not found in ConfigMap %s", ref.Key, ref.Name)
+		}
+		modelfile, err := parser.ParseFile(strings.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return modelfile.Commands, nil
+	}
+
+	var cmds []parser.Command
+	if create.From != "" {
+		cmds = append(cmds, parser.Command{Name: "model", Args: create.From})
+	}
+	if create.Template != "" {
+		cmds = append(cmds, parser.Command{Name: "template", Args: create.Template})
+	}
+	if create.System != "" {
+		cmds = append(cmds, parser.Command{Name: "system", Args: create.System})
+	}
+	for _, license := range create.License {
+		cmds = append(cmds, parser.Command{Name: "license", Args: license})
+	}
+	for _, param := range create.Parameters {
+		cmds = append(cmds, parser.Command{Name: param.Name, Args: param.Value})
+	}
+	for _, msg := range create.Messages {
+		cmds = append(cmds, parser.Command{Name: "message", Args: msg.Role + ": " + msg.Content})
+	}
+	return cmds, nil
+}
+
+// createRequest converts Modelfile commands to the create request, like parser.Modelfile.CreateRequest does, except
+// that it never reads local files - FROM must be a model name and adapters must be uploaded as blobs.
+// files and adapters map names of uploaded blobs to their digests.
+func createRequest(modelName string, cmds []parser.Command, files, adapters map[string]string) (*ollamaapi.CreateRequest, error) {
+	req := &ollamaapi.CreateRequest{Model: modelName, Stream: ptr.To(true), Files: files, Adapters: adapters}
+	var licenses []string
+	params := map[string][]string{}
+	for _, c := range cmds {
+		switch c.Name {
+		case "model":
+			if isLocalPath(c.Args) {
+				return nil, fmt.Errorf("FROM %s: models can't be created from local files", c.Args)
+			}
+			req.From = c.Args
+		case "adapter", "draft":
+			return nil, fmt.Errorf("%s is not supported, use files or adapters instead", strings.ToUpper(c.Name))
+		case "template":
+			req.Template = c.Args
+		case "system":
+			req.System = c.Args
+		case "license":
+			licenses = append(licenses, c.Args)
+		case "renderer":
+			req.Renderer = c.Args
+		case "parser":
+			req.Parser = c.Args
+		case "requires":
+			req.Requires = c.Args
+		case "message":
+			role, content, _ := strings.Cut(c.Args, ": ")
+			req.Messages = append(req.Messages, ollamaapi.Message{Role: role, Content: content})
+		default:
+			params[c.Name] = append(params[c.Name], c.Args)
+		}
+	}
+	if req.From == "" && len(req.Files) == 0 {
+		return nil, errors.New("FROM is required")
+	}
+	if len(licenses) > 0 {
+		req.License = licenses
+	}
+	if len(params) > 0 {
+		formatted, err := ollamaapi.FormatParams(params)
+		if err != nil {
+			return nil, err
+		}
+		req.Parameters = formatted
+	}
+	return req, nil
+}
+
+func isLocalPath(from string) bool {
+	return from == "." || strings.HasPrefix(from, "/") || strings.HasPrefix(from, "./") || strings.HasPrefix(from, "../") || strings.HasPrefix(from, "~")
+}
+
+func createHash(req *ollamaapi.CreateRequest) (string, error) {
+	// map keys are sorted by encoding/json, so the output is stable
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash create request")
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// createdModelsOn returns models created on the pod from the current Modelfiles, according to the previous replica statuses.
+func createdModelsOn(previous []ollamav1alpha1.ReplicaStatus, pod string, creates map[string]*modelCreate) map[string]string {
+	var created map[string]string
+	for _, replica := range previous {
+		if replica.Pod != pod {
+			continue
+		}
+		for name, hash := range replica.CreatedModels {
+			if c := creates[name]; c != nil && c.hash == hash {
+				if created == nil {
+					created = map[string]string{}
+				}
+				created[name] = hash
+			}
+		}
+	}
+	return created
+}
+
+func usesModelfileRef(desired []ollamav1alpha1.ModelEntry) bool {
+	for _, entry := range desired {
+		if entry.Create != nil && entry.Create.ModelfileRef != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ollama/ollama/api"
+	"github.com/ollama/ollama/parser"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func Test_createRequest(t *testing.T) {
+	want := &api.CreateRequest{
+		Model:    "assistant:latest",
+		Stream:   ptr.To(true),
+		From:     "llama3.1:8b",
+		System:   "You are a helpful assistant.",
+		License:  []string{"MIT"},
+		Messages: []api.Message{{Role: "user", Content: "Hi"}, {Role: "assistant", Content: "Hello!"}},
+		Parameters: map[string]any{
+			"temperature": float32(0.5),
+			"stop":        []string{"<|end|>", "<|user|>"},
+		},
+	}
+
+	t.Run("inline", func(t *testing.T) {
+		r := &Reconciler{}
+		cmds, err := r.modelfileCommands(context.Background(), &ollamav1alpha1.Model{}, &ollamav1alpha1.ModelCreate{
+			From:   "llama3.1:8b",
+			System: "You are a helpful assistant.",
+			Parameters: []ollamav1alpha1.ModelParameter{
+				{Name: "temperature", Value: "0.5"},
+				{Name: "stop", Value: "<|end|>"},
+				{Name: "stop", Value: "<|user|>"},
+			},
+			Messages: []ollamav1alpha1.ModelMessage{{Role: "user", Content: "Hi"}, {Role: "assistant", Content: "Hello!"}},
+			License:  []string{"MIT"},
+		})
+		require.NoError(t, err)
+		req, err := createRequest("assistant:latest", cmds, nil, nil)
+		require.NoError(t, err)
+		require.Empty(t, cmp.Diff(want, req))
+	})
+
+	t.Run("Modelfile", func(t *testing.T) {
+		modelfile, err := parser.ParseFile(strings.NewReader(`FROM llama3.1:8b
+SYSTEM You are a helpful assistant.
+PARAMETER temperature 0.5
+PARAMETER stop <|end|>
+PARAMETER stop <|user|>
+MESSAGE user Hi
+MESSAGE assistant Hello!
+LICENSE MIT
+`))
+		require.NoError(t, err)
+		req, err := createRequest("assistant:latest", modelfile.Commands, nil, nil)
+		require.NoError(t, err)
+		require.Empty(t, cmp.Diff(want, req))
+
+		hash, err := createHash(req)
+		require.NoError(t, err)
+		req.System = "You are a pirate."
+		changedHash, err := createHash(req)
+		require.NoError(t, err)
+		require.NotEqual(t, hash, changedHash)
+	})
+
+	for name, cmds := range map[string][]parser.Command{
+		"local file":        {{Name: "model", Args: "./model.gguf"}},
+		"adapter":           {{Name: "model", Args: "llama3.1:8b"}, {Name: "adapter", Args: "/adapters/lora.gguf"}},
+		"unknown parameter": {{Name: "model", Args: "llama3.1:8b"}, {Name: "foo", Args: "bar"}},
+		"no FROM":           {{Name: "system", Args: "You are a helpful assistant."}},
+	} {
+		t.Run("rejects "+name, func(t *testing.T) {
+			_, err := createRequest("assistant:latest", cmds, nil, nil)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestReconciler_createRequests_reusedUntilResync(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	reads := 0
+	r := &Reconciler{
+		apiReader: interceptor.NewClient(fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "modelfile", Namespace: "default"},
+			Data:       map[string]string{"Modelfile": "FROM llama3.1:8b"},
+		}).Build(), interceptor.Funcs{
+			Get: func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				reads++
+				return cli.Get(ctx, key, obj, opts...)
+			},
+		}),
+		timeNowFn: func() time.Time { return now },
+	}
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid", Generation: 1},
+		Spec: ollamav1alpha1.ModelSpec{Model: "assistant", Models: []ollamav1alpha1.ModelEntry{{
+			Name:   "custom",
+			Create: &ollamav1alpha1.ModelCreate{ModelfileRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "modelfile"}, Key: "Modelfile"}},
+		}}},
+	}
+
+	for range 3 {
+		creates, err := r.createRequests(context.Background(), model, model.DesiredModels())
+		require.NoError(t, err)
+		require.Equal(t, "llama3.1:8b", creates["custom"].req.From)
+	}
+	require.Equal(t, 1, reads, "reused on requeues")
+
+	model.SetUID("recreated")
+	_, err := r.createRequests(context.Background(), model, model.DesiredModels())
+	require.NoError(t, err)
+	require.Equal(t, 2, reads, "read again for a Model re-created under the same name")
+
+	model.SetGeneration(2)
+	_, err = r.createRequests(context.Background(), model, model.DesiredModels())
+	require.NoError(t, err)
+	require.Equal(t, 3, reads, "read again once the spec changes")
+
+	now = now.Add(modelfileResyncPeriod)
+	_, err = r.createRequests(context.Background(), model, model.DesiredModels())
+	require.NoError(t, err)
+	require.Equal(t, 4, reads, "read again after the resync period")
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	ollamaapi "github.com/ollama/ollama/api"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+// findListedModel returns the model from the List response, matching names the way Ollama does, e.g. "phi3" and "phi3:latest".
+func findListedModel(list *ollamaapi.ListResponse, modelName string) *ollamaapi.ListModelResponse {
+	for i := range list.Models {
+		if sameModel(list.Models[i].Model, modelName) {
+			return &list.Models[i]
+		}
+	}
+	return nil
+}
+
+// digestMatches reports whether the digest reported by Ollama matches the pinned one, which may be shortened like in `ollama list`.
+func digestMatches(pinned, actual string) bool {
+	pinned = strings.TrimPrefix(pinned, "sha256:")
+	actual = strings.TrimPrefix(actual, "sha256:")
+	return actual != "" && strings.HasPrefix(actual, pinned)
+}
+
+// setDigestMismatch reports that the model doesn't match its pinned digest, it's not pulled again until spec changes.
+func (r *Reconciler) setDigestMismatch(model *ollamav1alpha1.Model, entry ollamav1alpha1.ModelEntry, actual string) {
+	entryStatus := model.ModelStatusFor(entry.Name)
+	lastPullError := fmt.Sprintf("expected digest %s, got %s", entry.Digest, actual)
+	if entryStatus.FailureReason != ollamav1alpha1.PullFailureDigestMismatch || entryStatus.LastPullError != lastPullError {
+		r.eventRecorderFor(model).WarningEventf("PullingModel", "DigestMismatch", "Pulled %q model doesn't match its pinned digest: %s", entry.Name, lastPullError)
+		entryStatus.FailedGeneration = model.GetGeneration()
+	}
+	entryStatus.Digest = actual
+	entryStatus.PullState = ollamav1alpha1.PullStateFailed
+	entryStatus.FailureReason = ollamav1alpha1.PullFailureDigestMismatch
+	entryStatus.LastPullError = lastPullError
+	entryStatus.NextPullAttemptTime = nil
+	entryStatus.Message = fmt.Sprintf("%s, update the spec to retry", entryStatus.FailureReason)
+}
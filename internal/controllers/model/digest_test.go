@@ -0,0 +1,57 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/ollama/ollama/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/ollamaclient"
+)
+
+func Test_digestMatches(t *testing.T) {
+	const digest = "4f22229279388763d1a5d7ad7a14b6b5de4f81e8ab0a4cfb1c3d4e5f6a7b8c9d"
+	assert.True(t, digestMatches(digest, digest))
+	assert.True(t, digestMatches("sha256:"+digest, digest))
+	assert.True(t, digestMatches("4f2222927938", digest), "short digest from ollama list")
+	assert.False(t, digestMatches("a80c4f17acd5", digest))
+	assert.False(t, digestMatches("4f2222927938", ""))
+}
+
+func Test_findListedModel(t *testing.T) {
+	list := &api.ListResponse{Models: []api.ListModelResponse{
+		{Model: "phi3:latest", Digest: "a"},
+		{Model: "registry.example.com/library/llama3.1:8b", Digest: "b"},
+	}}
+	require.Equal(t, "a", findListedModel(list, "phi3").Digest)
+	require.Equal(t, "a", findListedModel(list, "phi3:latest").Digest)
+	require.Equal(t, "b", findListedModel(list, "registry.example.com/library/llama3.1:8b").Digest)
+	require.Nil(t, findListedModel(list, "llama3.1:8b"))
+	require.Nil(t, findListedModel(list, "phi3:mini"))
+}
+
+func TestReconciler_Reconcile_digestMismatchWithInitContainer(t *testing.T) {
+	c := newFakeModelCluster(t, &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default"},
+		Spec:       ollamav1alpha1.ModelSpec{Model: "phi3", Digest: "sha256:aaaa", PullMode: ollamav1alpha1.PullModeInitContainer},
+	}, &ollamaclient.TestOllamaClient{
+		OnList: func(context.Context) (*api.ListResponse, error) {
+			return &api.ListResponse{Models: []api.ListModelResponse{{Name: "phi3:latest", Model: "phi3:latest", Digest: "bbbb"}}}, nil
+		},
+	})
+
+	model := c.reconcile(t)
+	entryStatus := model.ModelStatusFor("phi3")
+	require.Equal(t, ollamav1alpha1.PullStateFailed, entryStatus.PullState)
+	require.Equal(t, ollamav1alpha1.PullFailureDigestMismatch, entryStatus.FailureReason)
+	stalled := model.GetCondition(ollamav1alpha1.TypeStalled)
+	require.Equal(t, corev1.ConditionTrue, stalled.Status)
+	require.Equal(t, xpv2.ConditionReason(ollamav1alpha1.PullFailureDigestMismatch), stalled.Reason)
+	require.Contains(t, model.GetCondition(xpv2.TypeReady).Message, "DigestMismatch")
+}
@@ -0,0 +1,262 @@
+package model
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	applynetworkingv1 "k8s.io/client-go/applyconfigurations/networking/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/applyconfig"
+	"aerf.io/ollama-operator/internal/commonmeta"
+	"aerf.io/ollama-operator/internal/defaults"
+	"aerf.io/ollama-operator/internal/patches"
+
+	"aerf.io/k8sutils"
+)
+
+// HTTPRouteGroupVersionKind is the GVK of Gateway API HTTPRoutes. Gateway API is an optional dependency of the cluster,
+// so HTTPRoutes are handled as unstructured objects.
+var HTTPRouteGroupVersionKind = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+var ingressGroupVersionKind = networkingv1.SchemeGroupVersion.WithKind("Ingress")
+
+func exposeType(expose *ollamav1alpha1.Expose) ollamav1alpha1.ExposeType {
+	return cmp.Or(expose.Type, ollamav1alpha1.ExposeTypeIngress)
+}
+
+// ExposeResource returns the Ingress or HTTPRoute from spec.expose of the Model, or nil if it's not set.
+func ExposeResource(model *ollamav1alpha1.Model) (*unstructured.Unstructured, error) {
+	expose := model.Spec.Expose
+	if expose == nil {
+		return nil, nil
+	}
+	labels := commonmeta.LabelsForResource(model.GetName(), map[string]string{modelLabelKey: model.GetName()})
+	serviceName := statefulSetName(model)
+	path := cmp.Or(expose.Path, "/")
+
+	var obj any
+	switch exposeType(expose) {
+	case ollamav1alpha1.ExposeTypeIngress:
+		rule := applynetworkingv1.IngressRule().
+			WithHTTP(applynetworkingv1.HTTPIngressRuleValue().
+				WithPaths(applynetworkingv1.HTTPIngressPath().
+					WithPath(path).
+					WithPathType(networkingv1.PathTypePrefix).
+					WithBackend(applynetworkingv1.IngressBackend().
+						WithService(applynetworkingv1.IngressServiceBackend().
+							WithName(serviceName).
+							WithPort(applynetworkingv1.ServiceBackendPort().WithNumber(defaults.OllamaPort)),
+						),
+					),
+				),
+			)
+		if expose.Host != "" {
+			rule.WithHost(expose.Host)
+		}
+		spec := applynetworkingv1.IngressSpec().WithRules(rule)
+		if expose.ClassName != nil {
+			spec.WithIngressClassName(*expose.ClassName)
+		}
+		if expose.TLSSecretRef != nil {
+			tls := applynetworkingv1.IngressTLS().WithSecretName(expose.TLSSecretRef.Name)
+			if expose.Host != "" {
+				tls.WithHosts(expose.Host)
+			}
+			spec.WithTLS(tls)
+		}
+		obj = applynetworkingv1.Ingress(model.GetName(), model.GetNamespace()).
+			WithLabels(labels).
+			WithOwnerReferences(applyconfig.ControllerReferenceFrom(model)).
+			WithSpec(spec)
+	case ollamav1alpha1.ExposeTypeHTTPRoute:
+		parentRef := map[string]any{"name": expose.GatewayRef.Name}
+		if expose.GatewayRef.Namespace != "" {
+			parentRef["namespace"] = expose.GatewayRef.Namespace
+		}
+		if expose.GatewayRef.SectionName != "" {
+			parentRef["sectionName"] = expose.GatewayRef.SectionName
+		}
+		spec := map[string]any{
+			"parentRefs": []any{parentRef},
+			"rules": []any{map[string]any{
+				"matches":     []any{map[string]any{"path": map[string]any{"type": "PathPrefix", "value": path}}},
+				"backendRefs": []any{map[string]any{"name": serviceName, "port": int64(defaults.OllamaPort)}},
+			}},
+		}
+		if expose.Host != "" {
+			spec["hostnames"] = []any{expose.Host}
+		}
+		route := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+		route.SetGroupVersionKind(HTTPRouteGroupVersionKind)
+		route.SetName(model.GetName())
+		route.SetNamespace(model.GetNamespace())
+		route.SetLabels(labels)
+		route.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(model, ollamav1alpha1.ModelGroupVersionKind)})
+		obj = route
+	default:
+		return nil, fmt.Errorf("unknown spec.expose.type %q", expose.Type)
+	}
+
+	patched, err := patches.Apply(obj, expose.Patches)
+	if err != nil {
+		return nil, err
+	}
+	return k8sutils.ToUnstructured(patched)
+}
+
+// applyExpose applies the Ingress or HTTPRoute from spec.expose, deletes the one generated for a previous spec and
+// reports whether it's admitted in the Exposed condition.
+func (r *Reconciler) applyExpose(ctx context.Context, model *ollamav1alpha1.Model) error {
+	expose := model.Spec.Expose
+	if exposed := model.Status.Expose; exposed != nil && (expose == nil || exposed.Kind != string(exposeType(expose))) {
+		if err := r.deleteExposed(ctx, model, exposed); err != nil {
+			return err
+		}
+		model.Status.Expose = nil
+	}
+	if expose == nil {
+		if model.GetCondition(ollamav1alpha1.TypeExposed).Status != corev1.ConditionUnknown {
+			setExposedCondition(model, corev1.ConditionFalse, ollamav1alpha1.ReasonNotExposed, "spec.expose is not set")
+		}
+		return nil
+	}
+	if exposeType(expose) == ollamav1alpha1.ExposeTypeHTTPRoute && !r.gatewayAPIInstalled {
+		setExposedCondition(model, corev1.ConditionFalse, ollamav1alpha1.ReasonExposeRejected, "HTTPRoutes are not served by the cluster, Gateway API has to be installed")
+		return nil
+	}
+
+	res, err := ExposeResource(model)
+	if err != nil {
+		return fmt.Errorf("while creating %s: %s", exposeType(expose), err)
+	}
+	ctrl.LoggerFrom(ctx).V(1).Info("Applying object", "object", res)
+	if err := r.apply(ctx, res); err != nil {
+		return fmt.Errorf("while applying %s %s: %s", res.GetKind(), res.GetName(), err)
+	}
+	model.Status.Expose = &ollamav1alpha1.ExposeStatus{Kind: res.GetKind(), Name: res.GetName()}
+
+	if exposeType(expose) == ollamav1alpha1.ExposeTypeIngress {
+		ingress := &networkingv1.Ingress{}
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(res), ingress); err != nil {
+			return errors.Wrap(err, "failed to fetch ingress")
+		}
+		observeIngress(model, ingress)
+		return nil
+	}
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(HTTPRouteGroupVersionKind)
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(res), route); err != nil {
+		return errors.Wrap(err, "failed to fetch httproute")
+	}
+	return observeHTTPRoute(model, route)
+}
+
+func (r *Reconciler) deleteExposed(ctx context.Context, model *ollamav1alpha1.Model, exposed *ollamav1alpha1.ExposeStatus) error {
+	obj := &unstructured.Unstructured{}
+	switch exposed.Kind {
+	case ingressGroupVersionKind.Kind:
+		obj.SetGroupVersionKind(ingressGroupVersionKind)
+	case HTTPRouteGroupVersionKind.Kind:
+		obj.SetGroupVersionKind(HTTPRouteGroupVersionKind)
+	default:
+		return nil
+	}
+	obj.SetName(exposed.Name)
+	obj.SetNamespace(model.GetNamespace())
+	if err := r.client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return errors.Wrapf(err, "failed to delete %s %s", exposed.Kind, exposed.Name)
+	}
+	return nil
+}
+
+// observeIngress reports the addresses of the Ingress, it's exposed once the ingress controller assigns one.
+func observeIngress(model *ollamav1alpha1.Model, ingress *networkingv1.Ingress) {
+	var addresses []string
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		addresses = append(addresses, cmp.Or(lb.Hostname, lb.IP))
+	}
+	model.Status.Expose.Addresses = addresses
+	if len(addresses) == 0 {
+		setExposedCondition(model, corev1.ConditionFalse, ollamav1alpha1.ReasonExposePending, "Waiting for the ingress controller to assign an address to the Ingress")
+		return
+	}
+
+	expose := model.Spec.Expose
+	scheme := "http"
+	if expose.TLSSecretRef != nil {
+		scheme = "https"
+	}
+	model.Status.Expose.URL = fmt.Sprintf("%s://%s%s", scheme, cmp.Or(expose.Host, addresses[0]), cmp.Or(expose.Path, "/"))
+	setExposedCondition(model, corev1.ConditionTrue, ollamav1alpha1.ReasonExposed, "")
+}
+
+// observeHTTPRoute reports whether the Gateway accepted the HTTPRoute and resolved its backend, based on the route's parent statuses.
+func observeHTTPRoute(model *ollamav1alpha1.Model, route *unstructured.Unstructured) error {
+	parents, _, err := unstructured.NestedSlice(route.Object, "status", "parents")
+	if err != nil {
+		return errors.Wrap(err, "failed to read httproute status")
+	}
+	accepted := false
+	for _, parent := range parents {
+		parentObj, ok := parent.(map[string]any)
+		if !ok {
+			return fmt.Errorf("unexpected type %T of httproute parent status", parent)
+		}
+		conditions, _, err := unstructured.NestedSlice(parentObj, "conditions")
+		if err != nil {
+			return errors.Wrap(err, "failed to read httproute parent conditions")
+		}
+		for _, c := range conditions {
+			condObj, ok := c.(map[string]any)
+			if !ok {
+				return fmt.Errorf("unexpected type %T of httproute condition", c)
+			}
+			cond := metav1.Condition{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(condObj, &cond); err != nil {
+				return errors.Wrap(err, "failed to read httproute condition")
+			}
+			if cond.Type != "Accepted" && cond.Type != "ResolvedRefs" {
+				continue
+			}
+			if cond.ObservedGeneration < route.GetGeneration() {
+				// not observed by the Gateway yet
+				continue
+			}
+			if cond.Status != metav1.ConditionTrue {
+				setExposedCondition(model, corev1.ConditionFalse, ollamav1alpha1.ReasonExposeRejected, fmt.Sprintf("HTTPRoute is not %s: %s: %s", cond.Type, cond.Reason, cond.Message))
+				return nil
+			}
+			accepted = accepted || cond.Type == "Accepted"
+		}
+	}
+	if !accepted {
+		setExposedCondition(model, corev1.ConditionFalse, ollamav1alpha1.ReasonExposePending, "Waiting for the Gateway to accept the HTTPRoute")
+		return nil
+	}
+	setExposedCondition(model, corev1.ConditionTrue, ollamav1alpha1.ReasonExposed, "")
+	return nil
+}
+
+func setExposedCondition(model *ollamav1alpha1.Model, status corev1.ConditionStatus, reason xpv2.ConditionReason, msg string) {
+	model.SetConditionsWithObservedGeneration(xpv2.Condition{
+		Type:               ollamav1alpha1.TypeExposed,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            msg,
+	})
+}
@@ -0,0 +1,154 @@
+package model
+
+import (
+	"testing"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func exposedModel(expose *ollamav1alpha1.Expose) *ollamav1alpha1.Model {
+	return &ollamav1alpha1.Model{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ollamav1alpha1.ModelGroupVersionKind.GroupVersion().String(), Kind: ollamav1alpha1.ModelKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default", UID: "uid"},
+		Spec: ollamav1alpha1.ModelSpec{
+			Model:  "phi3",
+			Expose: expose,
+			Auth:   &ollamav1alpha1.Auth{Keys: []ollamav1alpha1.APIKey{{Name: "chat-ui", Endpoints: []string{"/api/chat"}}}},
+		},
+	}
+}
+
+func TestExposeResource(t *testing.T) {
+	t.Run("not exposed", func(t *testing.T) {
+		res, err := ExposeResource(exposedModel(nil))
+		require.NoError(t, err)
+		require.Nil(t, res)
+	})
+
+	t.Run("Ingress", func(t *testing.T) {
+		res, err := ExposeResource(exposedModel(&ollamav1alpha1.Expose{
+			Host:         "ollama.example.com",
+			Path:         "/api",
+			ClassName:    ptr.To("nginx"),
+			TLSSecretRef: &corev1.LocalObjectReference{Name: "ollama-tls"},
+			Patches: &ollamav1alpha1.Patches{MergePatch: ollamav1alpha1.MergePatch{MergePatch: &runtime.RawExtension{
+				Raw: []byte(`{"metadata":{"annotations":{"nginx.ingress.kubernetes.io/proxy-read-timeout":"600"}}}`),
+			}}},
+		}))
+		require.NoError(t, err)
+		ingress := &networkingv1.Ingress{}
+		require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(res.Object, ingress))
+
+		require.Equal(t, "phi3", ingress.GetName())
+		require.Equal(t, ollamav1alpha1.ModelKind, ingress.GetOwnerReferences()[0].Kind)
+		require.Equal(t, "600", ingress.GetAnnotations()["nginx.ingress.kubernetes.io/proxy-read-timeout"])
+		require.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+		require.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"ollama.example.com"}, SecretName: "ollama-tls"}}, ingress.Spec.TLS)
+		rule := ingress.Spec.Rules[0]
+		require.Equal(t, "ollama.example.com", rule.Host)
+		require.Equal(t, "/api", rule.HTTP.Paths[0].Path)
+		require.Equal(t, "phi3", rule.HTTP.Paths[0].Backend.Service.Name)
+		require.Equal(t, int32(11434), rule.HTTP.Paths[0].Backend.Service.Port.Number)
+	})
+
+	t.Run("HTTPRoute", func(t *testing.T) {
+		model := exposedModel(&ollamav1alpha1.Expose{
+			Type:       ollamav1alpha1.ExposeTypeHTTPRoute,
+			Host:       "ollama.example.com",
+			GatewayRef: &ollamav1alpha1.GatewayReference{Name: "public", Namespace: "gateways", SectionName: "https"},
+		})
+		res, err := ExposeResource(model)
+		require.NoError(t, err)
+
+		require.Equal(t, HTTPRouteGroupVersionKind, res.GroupVersionKind())
+		require.Equal(t, "phi3", res.GetName())
+		require.Equal(t, map[string]any{
+			"hostnames":  []any{"ollama.example.com"},
+			"parentRefs": []any{map[string]any{"name": "public", "namespace": "gateways", "sectionName": "https"}},
+			"rules": []any{map[string]any{
+				"matches":     []any{map[string]any{"path": map[string]any{"type": "PathPrefix", "value": "/"}}},
+				"backendRefs": []any{map[string]any{"name": "phi3", "port": int64(11434)}},
+			}},
+		}, res.Object["spec"])
+	})
+}
+
+func Test_observeIngress(t *testing.T) {
+	model := exposedModel(&ollamav1alpha1.Expose{Host: "ollama.example.com", TLSSecretRef: &corev1.LocalObjectReference{Name: "tls"}})
+	model.Status.Expose = &ollamav1alpha1.ExposeStatus{Kind: "Ingress", Name: "phi3"}
+	ingress := &networkingv1.Ingress{}
+
+	observeIngress(model, ingress)
+	require.Equal(t, ollamav1alpha1.ReasonExposePending, model.GetCondition(ollamav1alpha1.TypeExposed).Reason)
+	require.Empty(t, model.Status.Expose.URL)
+
+	ingress.Status.LoadBalancer.Ingress = []networkingv1.IngressLoadBalancerIngress{{IP: "10.0.0.1"}}
+	observeIngress(model, ingress)
+	require.Equal(t, corev1.ConditionTrue, model.GetCondition(ollamav1alpha1.TypeExposed).Status)
+	require.Equal(t, ollamav1alpha1.ReasonExposed, model.GetCondition(ollamav1alpha1.TypeExposed).Reason)
+	require.Equal(t, []string{"10.0.0.1"}, model.Status.Expose.Addresses)
+	require.Equal(t, "https://ollama.example.com/", model.Status.Expose.URL)
+}
+
+func Test_observeHTTPRoute(t *testing.T) {
+	routeWith := func(conditions ...any) *unstructured.Unstructured {
+		route := &unstructured.Unstructured{Object: map[string]any{
+			"status": map[string]any{"parents": []any{map[string]any{"conditions": conditions}}},
+		}}
+		route.SetGeneration(2)
+		return route
+	}
+	condition := func(condType, status string, generation int64) map[string]any {
+		return map[string]any{"type": condType, "status": status, "reason": "Reason", "message": "message", "observedGeneration": generation}
+	}
+	tests := []struct {
+		name   string
+		route  *unstructured.Unstructured
+		status corev1.ConditionStatus
+		reason xpv2.ConditionReason
+	}{
+		{
+			name:   "no status",
+			route:  &unstructured.Unstructured{Object: map[string]any{}},
+			status: corev1.ConditionFalse,
+			reason: ollamav1alpha1.ReasonExposePending,
+		},
+		{
+			name:   "accepted",
+			route:  routeWith(condition("Accepted", "True", 2), condition("ResolvedRefs", "True", 2)),
+			status: corev1.ConditionTrue,
+			reason: ollamav1alpha1.ReasonExposed,
+		},
+		{
+			name:   "accepted previous generation",
+			route:  routeWith(condition("Accepted", "True", 1)),
+			status: corev1.ConditionFalse,
+			reason: ollamav1alpha1.ReasonExposePending,
+		},
+		{
+			name:   "unresolved backend",
+			route:  routeWith(condition("Accepted", "True", 2), condition("ResolvedRefs", "False", 2)),
+			status: corev1.ConditionFalse,
+			reason: ollamav1alpha1.ReasonExposeRejected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := exposedModel(&ollamav1alpha1.Expose{Type: ollamav1alpha1.ExposeTypeHTTPRoute})
+			model.Status.Expose = &ollamav1alpha1.ExposeStatus{Kind: "HTTPRoute", Name: "phi3"}
+			require.NoError(t, observeHTTPRoute(model, tt.route))
+			cond := model.GetCondition(ollamav1alpha1.TypeExposed)
+			require.Equal(t, tt.status, cond.Status)
+			require.Equal(t, tt.reason, cond.Reason)
+		})
+	}
+}
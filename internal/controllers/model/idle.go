@@ -0,0 +1,93 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+// idleCheckInterval is how often the load state of Models with spec.idle is checked for use.
+const idleCheckInterval = time.Minute
+
+// desiredReplicas returns the replicas of the StatefulSet, zero while the Model is suspended or scaled to zero because of spec.idle.
+func desiredReplicas(model *ollamav1alpha1.Model) int32 {
+	if model.Spec.Suspend || model.Status.ScaledToZero {
+		return 0
+	}
+	return ptr.Deref(model.Spec.Replicas, 1)
+}
+
+// observeIdle tracks the last use of a Model with spec.idle and decides whether it's scaled to zero. Prompts mark
+// the Model as used with ModelLastUsedAnnotation, models loaded in Ollama during the previous reconcile count as use too.
+// It returns when the use has to be checked again, zero if the Model is scaled to zero and waits for a Prompt.
+func (r *Reconciler) observeIdle(ctx context.Context, model *ollamav1alpha1.Model) time.Duration {
+	if model.Spec.Idle == nil {
+		model.Status.LastUsedTime = nil
+		model.Status.ScaledToZero = false
+		return 0
+	}
+
+	now := r.timeNowFn()
+	lastUsed := now
+	if model.Status.LastUsedTime != nil {
+		lastUsed = model.Status.LastUsedTime.Time
+	}
+	if value, ok := model.GetAnnotations()[ollamav1alpha1.ModelLastUsedAnnotation]; ok {
+		annotated, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			ctrl.LoggerFrom(ctx).Info("Ignoring invalid annotation", "annotation", ollamav1alpha1.ModelLastUsedAnnotation, "error", err.Error())
+		} else if annotated.After(lastUsed) {
+			lastUsed = annotated
+		}
+	}
+	if !model.Status.ScaledToZero && slices.ContainsFunc(model.Status.Models, func(status ollamav1alpha1.ModelEntryStatus) bool {
+		return status.Load != nil && status.Load.LoadedReplicas > 0
+	}) {
+		lastUsed = now
+	}
+	model.Status.LastUsedTime = ptr.To(metav1.NewTime(lastUsed))
+
+	idle := model.Spec.Idle.Duration
+	scaledToZero := now.Sub(lastUsed) >= idle
+	switch {
+	case scaledToZero && !model.Status.ScaledToZero:
+		r.eventRecorderFor(model).NormalEventf("ScalingDown", "ScaledToZero", "Scaled to zero, the Model was not used since %s", lastUsed.UTC().Format(time.RFC3339))
+	case !scaledToZero && model.Status.ScaledToZero:
+		r.eventRecorderFor(model).NormalEventf("ScalingUp", "WakingUp", "Scaling up from zero, the Model was used at %s", lastUsed.UTC().Format(time.RFC3339))
+	}
+	model.Status.ScaledToZero = scaledToZero
+	if scaledToZero {
+		return 0
+	}
+	return min(idle-now.Sub(lastUsed), idleCheckInterval)
+}
+
+// clearReplicaStatuses drops the state of replicas of a Model scaled to zero, it's gone with the pods.
+func clearReplicaStatuses(model *ollamav1alpha1.Model) {
+	model.Status.Replicas = nil
+	model.Status.ReadyReplicas = 0
+	for i := range model.Status.Models {
+		model.Status.Models[i].Load = nil
+	}
+}
+
+// setScaledToZero reports a Model scaled to zero because of spec.idle.
+func setScaledToZero(model *ollamav1alpha1.Model) {
+	clearReplicaStatuses(model)
+	model.SetConditionsWithObservedGeneration(xpv2.Condition{
+		Type:               xpv2.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ollamav1alpha1.ReasonScaledToZero,
+		Message:            fmt.Sprintf("Scaled to zero after being idle for %s, a new Prompt scales it up", model.Spec.Idle.Duration),
+	})
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func TestReconciler_observeIdle(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	r := &Reconciler{
+		recorder:  record.NewEventRecorderAdapter(record.NewFakeRecorder(100)),
+		timeNowFn: func() time.Time { return now },
+	}
+	newModel := func(lastUsed time.Time, scaledToZero bool) *ollamav1alpha1.Model {
+		return &ollamav1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec:       ollamav1alpha1.ModelSpec{Model: "phi3", Replicas: ptr.To(int32(2)), Idle: &metav1.Duration{Duration: time.Hour}},
+			Status: ollamav1alpha1.ModelStatus{
+				LastUsedTime: ptr.To(metav1.NewTime(lastUsed)),
+				ScaledToZero: scaledToZero,
+				Models:       []ollamav1alpha1.ModelEntryStatus{{Name: "phi3"}},
+			},
+		}
+	}
+
+	t.Run("used recently", func(t *testing.T) {
+		model := newModel(now.Add(-50*time.Minute), false)
+		require.Equal(t, time.Minute, r.observeIdle(context.Background(), model))
+		require.False(t, model.Status.ScaledToZero)
+		require.Equal(t, int32(2), desiredReplicas(model))
+
+		model = newModel(now.Add(-59*time.Minute-30*time.Second), false)
+		require.Equal(t, 30*time.Second, r.observeIdle(context.Background(), model))
+	})
+
+	t.Run("idle", func(t *testing.T) {
+		model := newModel(now.Add(-2*time.Hour), false)
+		require.Zero(t, r.observeIdle(context.Background(), model))
+		require.True(t, model.Status.ScaledToZero)
+		require.Equal(t, int32(0), desiredReplicas(model))
+	})
+
+	t.Run("loaded models count as use", func(t *testing.T) {
+		model := newModel(now.Add(-2*time.Hour), false)
+		model.Status.Models[0].Load = &ollamav1alpha1.ModelLoadStatus{LoadedReplicas: 1}
+		r.observeIdle(context.Background(), model)
+		require.False(t, model.Status.ScaledToZero)
+		require.Equal(t, now, model.Status.LastUsedTime.Time)
+	})
+
+	t.Run("prompt wakes the Model up", func(t *testing.T) {
+		model := newModel(now.Add(-2*time.Hour), true)
+		r.observeIdle(context.Background(), model)
+		require.True(t, model.Status.ScaledToZero)
+
+		model.SetAnnotations(map[string]string{ollamav1alpha1.ModelLastUsedAnnotation: now.Add(-time.Second).Format(time.RFC3339)})
+		r.observeIdle(context.Background(), model)
+		require.False(t, model.Status.ScaledToZero)
+		require.Equal(t, int32(2), desiredReplicas(model))
+	})
+
+	t.Run("idle removed", func(t *testing.T) {
+		model := newModel(now.Add(-2*time.Hour), true)
+		model.Spec.Idle = nil
+		require.Zero(t, r.observeIdle(context.Background(), model))
+		require.False(t, model.Status.ScaledToZero)
+		require.Nil(t, model.Status.LastUsedTime)
+	})
+}
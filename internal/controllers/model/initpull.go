@@ -0,0 +1,112 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+const pullInitContainerName = "pull"
+
+// pullInitScript starts a temporary Ollama server, pulls the models passed as arguments unless they're present, e.g.
+// seeded from spec.source, and exits, which stops the server too. The reason of a failed pull is written to the
+// termination message, see observeInitContainerPulls.
+const pullInitScript = `ollama serve &
+until ollama list >/dev/null 2>&1; do sleep 1; done
+for model in "$@"; do
+  if ollama show "$model" >/dev/null 2>&1; then
+    echo "$model is present"
+    continue
+  fi
+  echo "pulling $model"
+  if ! ollama pull ${OLLAMA_PULL_FLAGS} "$model" >/tmp/pull.log 2>&1; then
+    echo "failed to pull $model: $(tail -n 1 /tmp/pull.log)" | tee /dev/termination-log
+    exit 1
+  fi
+done
+`
+
+func pullsInInitContainer(model *ollamav1alpha1.Model) bool {
+	return model.Spec.PullMode == ollamav1alpha1.PullModeInitContainer
+}
+
+// initContainerPulledModels returns the desired models pulled by the init container, created models are left to the operator.
+func initContainerPulledModels(model *ollamav1alpha1.Model) []string {
+	var names []string
+	for _, entry := range model.DesiredModels() {
+		if entry.Create == nil {
+			names = append(names, entry.Name)
+		}
+	}
+	return names
+}
+
+// pullInitContainer returns the init container pulling the models with spec.pullMode InitContainer, or nil otherwise.
+// Models can't be pulled into an image volume from spec.source, so it's not added then, missing models are stalled.
+// It runs the image of the Ollama container with its env and volume mounts, so the models end up on the same volume
+// and registry credentials or spec.server configuration apply to the pulls too.
+func pullInitContainer(model *ollamav1alpha1.Model, ollama *applycorev1.ContainerApplyConfiguration) *applycorev1.ContainerApplyConfiguration {
+	models := initContainerPulledModels(model)
+	if !pullsInInitContainer(model) || seedsFromImage(model) || len(models) == 0 {
+		return nil
+	}
+	container := applycorev1.Container().
+		WithName(pullInitContainerName).
+		WithImage(*ollama.Image).
+		WithImagePullPolicy(corev1.PullIfNotPresent).
+		WithCommand("/bin/sh", "-c", pullInitScript, pullInitContainerName).
+		WithArgs(models...)
+	container.Env = slices.Clone(ollama.Env)
+	container.VolumeMounts = slices.Clone(ollama.VolumeMounts)
+	if insecureRegistry(model) {
+		container.WithEnv(applycorev1.EnvVar().WithName("OLLAMA_PULL_FLAGS").WithValue("--insecure"))
+	}
+	return container
+}
+
+// observeInitContainerPulls reports the state of the pull init containers of the pods in statuses of the pulled models.
+// It returns a message describing the first pod still pulling or failing to pull, or an empty one if there's none.
+func observeInitContainerPulls(model *ollamav1alpha1.Model, pods []corev1.Pod) string {
+	models := initContainerPulledModels(model)
+	setPullState := func(state ollamav1alpha1.PullState, lastError, message string) {
+		for _, name := range models {
+			entryStatus := setModelStatus(model, ollamav1alpha1.ModelEntryStatus{Name: name})
+			entryStatus.PullState = state
+			entryStatus.LastPullError = lastError
+			entryStatus.Message = message
+		}
+	}
+
+	for i := range pods {
+		for _, status := range pods[i].Status.InitContainerStatuses {
+			if status.Name != pullInitContainerName {
+				continue
+			}
+			terminated := status.State.Terminated
+			if terminated == nil && status.State.Waiting != nil {
+				// restarted with a backoff after a failure
+				terminated = status.LastTerminationState.Terminated
+			}
+			switch {
+			case status.State.Running != nil:
+				msg := fmt.Sprintf("Pulling models in the init container of pod %q", pods[i].GetName())
+				setPullState(ollamav1alpha1.PullStatePulling, "", fmt.Sprintf("%s since %s", msg, status.State.Running.StartedAt.UTC().Format(time.RFC3339)))
+				return msg
+			case terminated != nil && terminated.ExitCode != 0:
+				reason := terminated.Message
+				if reason == "" {
+					reason = fmt.Sprintf("exit code %d", terminated.ExitCode)
+				}
+				msg := fmt.Sprintf("Init container of pod %q failed to pull models: %s", pods[i].GetName(), reason)
+				setPullState(ollamav1alpha1.PullStateFailed, reason, msg)
+				return msg
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func TestResources_pullInitContainer(t *testing.T) {
+	model := &ollamav1alpha1.Model{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ollamav1alpha1.ModelGroupVersionKind.GroupVersion().String(), Kind: ollamav1alpha1.ModelKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default", UID: "uid"},
+		Spec: ollamav1alpha1.ModelSpec{
+			Model:    "phi3",
+			PullMode: ollamav1alpha1.PullModeInitContainer,
+			Models: []ollamav1alpha1.ModelEntry{
+				{Name: "llama3.1"},
+				{Name: "custom", Create: &ollamav1alpha1.ModelCreate{From: "llama3.1"}},
+			},
+			Registry: &ollamav1alpha1.Registry{Insecure: true},
+		},
+	}
+
+	initContainers := statefulSetOf(t, model, Operator{}).Spec.Template.Spec.InitContainers
+	require.Len(t, initContainers, 1)
+	pull := initContainers[0]
+	require.Equal(t, pullInitContainerName, pull.Name)
+	require.Equal(t, []string{"phi3", "llama3.1"}, pull.Args, "created models are left to the operator")
+	require.Contains(t, pull.Env, corev1.EnvVar{Name: "OLLAMA_PULL_FLAGS", Value: "--insecure"})
+	require.Contains(t, pull.VolumeMounts, corev1.VolumeMount{Name: "phi3-ollama-root", MountPath: "/root/.ollama"})
+
+	model.Spec.PullMode = ollamav1alpha1.PullModeOperator
+	require.Empty(t, statefulSetOf(t, model, Operator{}).Spec.Template.Spec.InitContainers)
+}
+
+func Test_observeInitContainerPulls(t *testing.T) {
+	startedAt := metav1.NewTime(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	podWith := func(status corev1.ContainerStatus) corev1.Pod {
+		status.Name = pullInitContainerName
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "phi3-0"},
+			Status:     corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{status}},
+		}
+	}
+	tests := []struct {
+		name          string
+		pod           corev1.Pod
+		wantMsg       string
+		wantPullState ollamav1alpha1.PullState
+		wantLastError string
+	}{
+		{
+			name:          "pulling",
+			pod:           podWith(corev1.ContainerStatus{State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: startedAt}}}),
+			wantMsg:       `Pulling models in the init container of pod "phi3-0"`,
+			wantPullState: ollamav1alpha1.PullStatePulling,
+		},
+		{
+			name: "failed",
+			pod: podWith(corev1.ContainerStatus{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 1,
+				Message:  "failed to pull phi3: Error: pull model manifest: file does not exist",
+			}}}),
+			wantMsg:       `Init container of pod "phi3-0" failed to pull models: failed to pull phi3: Error: pull model manifest: file does not exist`,
+			wantPullState: ollamav1alpha1.PullStateFailed,
+			wantLastError: "failed to pull phi3: Error: pull model manifest: file does not exist",
+		},
+		{
+			name: "backing off",
+			pod: podWith(corev1.ContainerStatus{
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137}},
+			}),
+			wantMsg:       `Init container of pod "phi3-0" failed to pull models: exit code 137`,
+			wantPullState: ollamav1alpha1.PullStateFailed,
+			wantLastError: "exit code 137",
+		},
+		{
+			name: "finished",
+			pod:  podWith(corev1.ContainerStatus{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &ollamav1alpha1.Model{Spec: ollamav1alpha1.ModelSpec{Model: "phi3", PullMode: ollamav1alpha1.PullModeInitContainer}}
+			require.Equal(t, tt.wantMsg, observeInitContainerPulls(model, []corev1.Pod{tt.pod}))
+			if tt.wantMsg == "" {
+				require.Empty(t, model.Status.Models)
+				return
+			}
+			entryStatus := model.ModelStatusFor("phi3")
+			require.Equal(t, tt.wantPullState, entryStatus.PullState)
+			require.Equal(t, tt.wantLastError, entryStatus.LastPullError)
+		})
+	}
+}
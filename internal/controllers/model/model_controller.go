@@ -19,12 +19,11 @@ package model
 import (
 	"cmp"
 	"context"
-	"encoding/json"
 	"fmt"
-	"math"
 	"net/http"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
@@ -35,8 +34,10 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	apimachineryresource "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -45,15 +46,19 @@ import (
 	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/kubectl/pkg/cmd/util/podcmd"
 	"k8s.io/kubectl/pkg/polymorphichelpers"
+	"k8s.io/kubectl/pkg/util/podutils"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
-	applyollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1/applyconfiguration/ollama/v1alpha1"
 	"aerf.io/ollama-operator/internal/applyconfig"
 	"aerf.io/ollama-operator/internal/commonmeta"
 	"aerf.io/ollama-operator/internal/defaults"
@@ -67,11 +72,22 @@ import (
 
 type Reconciler struct {
 	client               client.Client
+	apiReader            client.Reader
 	recorder             events.EventRecorder
 	baseHTTPClient       *http.Client
 	tp                   trace.TracerProvider
 	ollamaClientProvider ollamaclient.ClientProvider
+	pulls                *pullManager
 	timeNowFn            func() time.Time
+	// gatewayAPIInstalled is true if the cluster serves HTTPRoutes
+	gatewayAPIInstalled bool
+	operator            Operator
+	// adminKeys holds the operator's key of each Model with spec.auth, see applyAPIKeys
+	adminKeys sync.Map
+	// creates holds the create requests last read for each Model, see createRequests
+	creates sync.Map
+	// blobServerTokens holds the credentials of the blob-server sidecar of each Model importing files from PVCs, see applyBlobServerSecret
+	blobServerTokens sync.Map
 }
 
 func (r *Reconciler) apply(ctx context.Context, obj *unstructured.Unstructured, opts ...client.ApplyOption) error {
@@ -83,15 +99,25 @@ func (r *Reconciler) eventRecorderFor(obj runtime.Object) *eventrecorder.EventRe
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, model *ollamav1alpha1.Model) (result ctrl.Result, retErr error) {
+	if model.GetDeletionTimestamp() != nil {
+		r.pulls.cancelStale(client.ObjectKeyFromObject(model), func(pullKey) bool { return false })
+		return ctrl.Result{}, r.finalize(ctx, model)
+	}
+
+	ollamaImage := cmp.Or(model.Spec.OllamaImage, defaults.OllamaImage)
+	var idleRequeueAfter time.Duration
 	defer func() {
 		model.Status.ObservedGeneration = model.GetGeneration()
-		model.Status.OllamaImage = cmp.Or(model.Spec.OllamaImage, defaults.OllamaImage)
+		model.Status.OllamaImage = ollamaImage
 		if retErr != nil {
 			model.SetConditionsWithObservedGeneration(xpv2.ReconcileError(retErr))
 			model.SetConditionsWithObservedGeneration(xpv2.Unavailable()) // if the reconcile failed we can't say anything about the Model's status
 		} else {
 			model.SetConditionsWithObservedGeneration(xpv2.ReconcileSuccess())
 		}
+		if idleRequeueAfter > 0 && (result.RequeueAfter == 0 || idleRequeueAfter < result.RequeueAfter) {
+			result.RequeueAfter = idleRequeueAfter
+		}
 
 		patchErr := r.client.Status().Update(ctx, model)
 		if patchErr != nil {
@@ -101,26 +127,57 @@ func (r *Reconciler) Reconcile(ctx context.Context, model *ollamav1alpha1.Model)
 
 	log := ctrl.LoggerFrom(ctx)
 	log.V(1).Info("Reconciling Model", "object", model)
-	recorder := r.eventRecorderFor(model)
 
-	ollamaCli := r.ollamaClientProvider.ForModel(model)
-
-	resources, err := Resources(model)
-	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("while creating resources: %s", err)
+	if err := r.ensureFinalizer(ctx, model); err != nil {
+		return ctrl.Result{}, err
 	}
+	if isPaused(model) {
+		return ctrl.Result{}, r.observePaused(ctx, model)
+	}
+	r.setResumed(model)
 
-	for _, res := range resources {
-		log.V(1).Info("Applying object", "object", res)
-		if err := r.apply(ctx, res); err != nil {
-			return ctrl.Result{}, fmt.Errorf("while applying %s %s: %s", res.GetKind(), res.GetName(), err)
+	replicas := ptr.Deref(model.Spec.Replicas, 1)
+	model.Status.RetentionPolicy = ""
+	if model.Spec.ServerRef == nil {
+		model.Status.RetentionPolicy = retentionPolicy(model)
+		idleRequeueAfter = r.observeIdle(ctx, model)
+		if err := r.applyResources(ctx, model); err != nil {
+			return ctrl.Result{}, err
 		}
+	} else {
+		server := &ollamav1alpha1.OllamaServer{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: model.GetNamespace(), Name: model.Spec.ServerRef.Name}, server); err != nil {
+			if apierrors.IsNotFound(err) {
+				model.SetConditionsWithObservedGeneration(xpv2.Unavailable().WithMessage(fmt.Sprintf("OllamaServer %q does not exist", model.Spec.ServerRef.Name)))
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, errors.Wrap(err, "failed to fetch OllamaServer")
+		}
+		if err := checkServerSupport(model); err != nil {
+			return ctrl.Result{}, err
+		}
+		ollamaImage = cmp.Or(server.Spec.OllamaImage, defaults.OllamaImage)
+		replicas = ptr.Deref(server.Spec.Replicas, 1)
+	}
+	if err := r.applyExpose(ctx, model); err != nil {
+		return ctrl.Result{}, err
+	}
+	if model.Spec.Suspend {
+		r.pulls.cancelStale(client.ObjectKeyFromObject(model), func(pullKey) bool { return false })
+		r.setSuspended(model)
+		return ctrl.Result{}, nil
+	}
+	r.setUnsuspended(model)
+	if model.Status.ScaledToZero {
+		r.pulls.cancelStale(client.ObjectKeyFromObject(model), func(pullKey) bool { return false })
+		setScaledToZero(model)
+		return ctrl.Result{}, nil
 	}
 
 	sts := &appsv1.StatefulSet{}
 	if err := r.client.Get(ctx, client.ObjectKey{
 		Namespace: model.GetNamespace(),
-		Name:      model.GetName(),
+		Name:      statefulSetName(model),
 	}, sts); err != nil {
 		if apierrors.IsNotFound(err) {
 			model.SetConditionsWithObservedGeneration(xpv2.Creating())
@@ -129,112 +186,560 @@ func (r *Reconciler) Reconcile(ctx context.Context, model *ollamav1alpha1.Model)
 		return ctrl.Result{}, errors.Wrap(err, "failed to fetch statefulset to check its readiness")
 	}
 
-	readyMsg, ready, err := isStatefulSetReady(sts)
+	readyMsg, ready, err := IsStatefulSetReady(sts)
 	if err != nil {
 		model.SetConditionsWithObservedGeneration(xpv2.Unavailable())
 		return ctrl.Result{}, err
 	}
 	if !ready {
+		if pullsInInitContainer(model) {
+			pods, err := r.listOllamaPods(ctx, model)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if msg := observeInitContainerPulls(model, pods); msg != "" {
+				model.SetConditionsWithObservedGeneration(xpv2.Creating().WithMessage(msg))
+				return ctrl.Result{}, nil
+			}
+		}
 		model.SetConditionsWithObservedGeneration(xpv2.Unavailable().WithMessage(readyMsg))
 		return ctrl.Result{}, nil
 	}
 
-	modelList, err := ollamaCli.List(ctx)
+	pods, err := r.listOllamaPods(ctx, model)
 	if err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "failed to list local models")
+		return ctrl.Result{}, err
+	}
+	if len(pods) == 0 {
+		model.SetConditionsWithObservedGeneration(xpv2.Unavailable().WithMessage("Waiting for Ollama pods"))
+		return ctrl.Result{}, nil
 	}
 
-	if !slices.ContainsFunc(modelList.Models, func(resp ollamaapi.ListModelResponse) bool { return resp.Model == model.Spec.Model }) {
-		// model has NOT been pulled in yet
-		pullingModelCondition := xpv2.Creating().WithMessage(fmt.Sprintf("Pulling %q model", model.Spec.Model))
-		cond := model.GetCondition(xpv2.TypeReady)
-		if !cond.Equal(pullingModelCondition) {
-			// pulling takes a while and we want to inform the user that it's happening
-			model.SetConditionsWithObservedGeneration(pullingModelCondition)
-			return ctrl.Result{Requeue: true}, nil
+	desiredModels := model.DesiredModels()
+	creates, err := r.createRequests(ctx, model, desiredModels)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	previousReplicas := model.Status.Replicas
+	mismatched := map[string]string{}
+	model.Status.Replicas = make([]ollamav1alpha1.ReplicaStatus, 0, len(pods))
+	for i := range pods {
+		modelList, err := r.ollamaClientFor(model, &pods[i]).List(ctx)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to list local models")
 		}
+		created := createdModelsOn(previousReplicas, pods[i].GetName(), creates)
+		present := make([]string, 0, len(modelList.Models))
+		for _, entry := range desiredModels {
+			resp := findListedModel(modelList, entry.Name)
+			if resp == nil {
+				continue
+			}
+			if create := creates[entry.Name]; create != nil && created[entry.Name] != create.hash {
+				// created from an outdated Modelfile, it has to be re-created
+				continue
+			}
+			if entry.Digest != "" && !digestMatches(entry.Digest, resp.Digest) {
+				mismatched[entry.Name] = resp.Digest
+				continue
+			}
+			present = append(present, entry.Name)
+		}
+		replica := replicaStatus(&pods[i], present, desiredModels)
+		replica.CreatedModels = created
+		model.Status.Replicas = append(model.Status.Replicas, replica)
+	}
+	if err := r.deleteStaleModels(ctx, model, pods, desiredModels); err != nil {
+		return ctrl.Result{}, err
+	}
+	updateModelStatuses(model, desiredModels)
+	for _, entry := range desiredModels {
+		if digest, ok := mismatched[entry.Name]; ok {
+			r.setDigestMismatch(model, entry, digest)
+		}
+	}
 
-		log.V(1).Info("started pulling ollama model")
-		recorder.NormalEventf("PullingModel", "PullingModel", "Pulling %q model", model.Spec.Model)
+	modelKey := client.ObjectKeyFromObject(model)
+	for _, key := range r.pulls.cancelStale(modelKey, func(key pullKey) bool {
+		return slices.ContainsFunc(desiredModels, func(e ollamav1alpha1.ModelEntry) bool { return e.Name == key.modelName }) &&
+			slices.ContainsFunc(pods, func(pod corev1.Pod) bool { return pod.GetName() == key.pod })
+	}) {
+		log.V(1).Info("cancelled pull of no longer desired model", "model", key.modelName, "pod", key.pod)
+	}
 
-		pullResp := ollamaapi.ProgressResponse{}
+	for i := range model.Status.Models {
+		if model.Status.Models[i].FailedGeneration != model.GetGeneration() {
+			// spec changed since the pull failed, it might succeed now
+			resetPullFailure(&model.Status.Models[i])
+		}
+	}
 
-		pullInitialTime := r.timeNowFn()
-		if err := ollamaCli.Pull(ctx, &ollamaapi.PullRequest{
-			Model:  model.Spec.Model,
-			Stream: ptr.To(true),
-		}, func(resp ollamaapi.ProgressResponse) error {
-			log.V(4).Info("pulling model...", "progressResponse", resp)
-			pullResp = resp
+	var pulling, missing []pullKey
+	var stalled, backingOff []string
+	for i := range pods {
+		for _, entry := range desiredModels {
+			key := pullKey{model: modelKey, pod: pods[i].GetName(), modelName: entry.Name}
+			if slices.Contains(model.Status.Replicas[i].Models, entry.Name) {
+				r.pulls.forget(key)
+				continue
+			}
 
-			if resp.Total != 0 {
-				if time.Since(pullInitialTime) > 5*time.Second {
-					pullInitialTime = r.timeNowFn()
-					return r.patchModelStatusOnPullingProgress(ctx, model, fmt.Sprintf("Progress in pulling model layer: %.2f%%, digest: %q", math.RoundToEven(float64(resp.Completed*100)/float64(resp.Total)), resp.Digest))
+			if seedsFromImage(model) {
+				r.setNotInSeedImage(model, entry)
+				stalled = appendIfMissing(stalled, entry.Name)
+				continue
+			}
+			create := creates[entry.Name]
+			if create == nil && pullsInInitContainer(model) {
+				if model.ModelStatusFor(entry.Name).FailureReason != "" {
+					// e.g. a digest mismatch, pulling it again in a restarted pod won't help until spec changes
+					stalled = appendIfMissing(stalled, entry.Name)
+					break
 				}
+				// pulled by the init container before the pod started, the pod has to be restarted to pull it again
+				missing = append(missing, key)
+				break
+			}
+			outcome := r.observePull(ctx, &pods[i], model, key, create)
+			if outcome == pullSucceeded && entry.Digest != "" {
+				// verified by the next List, the pull finished event requeues the Model right away
+				pulling = append(pulling, key)
+				break
 			}
+			if outcome == pullSucceeded {
+				model.Status.Replicas[i].Models = append(model.Status.Replicas[i].Models, entry.Name)
+				if create != nil {
+					if model.Status.Replicas[i].CreatedModels == nil {
+						model.Status.Replicas[i].CreatedModels = map[string]string{}
+					}
+					model.Status.Replicas[i].CreatedModels[entry.Name] = create.hash
+				}
+				continue
+			}
+			switch outcome {
+			case pullInProgress:
+				pulling = append(pulling, key)
+			case pullStalled:
+				stalled = appendIfMissing(stalled, entry.Name)
+			case pullBackingOff:
+				backingOff = appendIfMissing(backingOff, entry.Name)
+			}
+			break // pull one model at a time on every pod
+		}
+		created := model.Status.Replicas[i].CreatedModels
+		model.Status.Replicas[i] = replicaStatus(&pods[i], model.Status.Replicas[i].Models, desiredModels)
+		model.Status.Replicas[i].CreatedModels = created
+	}
+	updateModelStatuses(model, desiredModels)
+	r.setStalledCondition(model, stalled)
+
+	switch {
+	case len(pulling) > 0:
+		verb := "Pulling"
+		if _, ok := creates[pulling[0].modelName]; ok {
+			verb = "Creating"
+		}
+		msg := fmt.Sprintf("%s %q model on pod %q", verb, pulling[0].modelName, pulling[0].pod)
+		if model.Status.Pull != nil && model.Status.Pull.TotalBytes > 0 {
+			msg = fmt.Sprintf("%s: %d%%", msg, model.Status.Pull.Percent)
+		}
+		model.SetConditionsWithObservedGeneration(xpv2.Creating().WithMessage(msg))
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	case len(missing) > 0:
+		model.SetConditionsWithObservedGeneration(xpv2.Unavailable().WithMessage(fmt.Sprintf("Model %q is missing on pod %q, it's pulled by the init container when the pod starts", missing[0].modelName, missing[0].pod)))
+		return ctrl.Result{}, nil
+	case len(stalled) > 0 || len(backingOff) > 0:
+		failed := model.ModelStatusFor(slices.Concat(stalled, backingOff)[0])
+		model.SetConditionsWithObservedGeneration(xpv2.Unavailable().WithMessage(fmt.Sprintf("Failed to pull %q model: %s", failed.Name, failed.Message)))
+		return ctrl.Result{RequeueAfter: r.nextPullAttemptIn(model, slices.Concat(stalled, backingOff))}, nil
+	}
 
-			return ctx.Err() // return early on context cancel/timeout
-		}); err != nil {
-			recorder.WarningEventf("PullingModel", "PullingModel", "failed to pull %q model", model.Spec.Model)
-			return ctrl.Result{}, errors.Wrapf(err, "failed to pull %q model", model.Spec.Model)
+	ollamaCli := r.ollamaClientFor(model, &pods[0])
+	modelList, err := ollamaCli.List(ctx)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to list local models")
+	}
+	for _, entry := range desiredModels {
+		entryStatus := model.ModelStatusFor(entry.Name)
+		// while the model is being updated, the digest before the update is kept until all replicas are updated
+		if resp := findListedModel(modelList, entry.Name); resp != nil && !r.updating(model, entry.Name) {
+			entryStatus.Digest = resp.Digest
+			entryStatus.Size = resp.Size
+			entryStatus.ModifiedAt = ptr.To(metav1.NewTime(resp.ModifiedAt))
 		}
-		log.V(1).Info("pulled model", "response", pullResp)
-		if pullResp.Status != "success" {
-			model.SetConditionsWithObservedGeneration(xpv2.Unavailable().WithMessage("Model hasn't been pulled successfully, retrying"))
-			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		if create := creates[entry.Name]; create == nil {
+			entryStatus.CreateHash = ""
+		} else if entryStatus.CreateHash != create.hash {
+			entryStatus.CreateHash = create.hash
+			r.eventRecorderFor(model).NormalEventf("CreatingModel", "CreatedModel", "Created %q model on all replicas, digest %s", entry.Name, entryStatus.Digest)
 		}
+		modelDetails, err := ollamaCli.Show(ctx, &ollamaapi.ShowRequest{Model: entry.Name})
+		if err != nil {
+			model.SetConditionsWithObservedGeneration(xpv2.Unavailable())
+			return ctrl.Result{}, errors.Wrapf(err, "while fetching ollama model %q details", entry.Name)
+		}
+		entryStatus.Details = ollamaModelDetails(modelDetails)
+		entryStatus.Ready = true
+		entryStatus.Message = ""
+	}
+	if len(model.Status.Models) > 0 {
+		model.Status.OllamaModelDetails = model.Status.Models[0].Details
 	}
 
-	modelDetails, err := ollamaCli.Show(ctx, &ollamaapi.ShowRequest{Model: model.Spec.Model})
+	loading, err := r.observeLoadState(ctx, model, pods, desiredModels)
 	if err != nil {
-		model.SetConditionsWithObservedGeneration(xpv2.Unavailable())
-		return ctrl.Result{}, errors.Wrap(err, "while fetching ollama model details")
+		return ctrl.Result{}, err
 	}
-	model.Status.OllamaModelDetails = &ollamav1alpha1.OllamaModelDetails{
-		ParameterSize:     modelDetails.Details.ParameterSize,
-		QuantizationLevel: modelDetails.Details.QuantizationLevel,
-		ParentModel:       modelDetails.Details.ParentModel,
-		Format:            modelDetails.Details.Format,
-		Family:            modelDetails.Details.Family,
-		Families:          modelDetails.Details.Families,
+	if len(loading) > 0 {
+		model.SetConditionsWithObservedGeneration(xpv2.Creating().WithMessage(fmt.Sprintf("Loading %q model on pod %q", loading[0].modelName, loading[0].pod)))
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	if model.Status.ReadyReplicas < replicas {
+		model.SetConditionsWithObservedGeneration(xpv2.Unavailable().WithMessage(fmt.Sprintf("%d/%d replicas have all models pulled", model.Status.ReadyReplicas, replicas)))
+		return ctrl.Result{}, nil
 	}
 
+	requeueAfter, err := r.observeUpdates(ctx, model, pods, desiredModels)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if usesModelfileRef(desiredModels) && (requeueAfter == 0 || modelfileResyncPeriod < requeueAfter) {
+		requeueAfter = modelfileResyncPeriod
+	}
 	model.SetConditionsWithObservedGeneration(xpv2.Available())
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
-func (r *Reconciler) patchModelStatusOnPullingProgress(ctx context.Context, model *ollamav1alpha1.Model, msg string) error {
-	return r.client.Status().Patch(ctx, model, applyPatch{
-		obj: applyollamav1alpha1.Model(model.GetName(), model.GetNamespace()).
-			WithStatus(applyollamav1alpha1.ModelStatus().
-				WithObservedGeneration(model.GetGeneration()).
-				WithConditions(
-					xpv2.Creating().
-						WithObservedGeneration(model.GetGeneration()).
-						WithMessage(msg),
-					xpv2.ReconcileSuccess().
-						WithObservedGeneration(model.GetGeneration()),
-				),
-			),
-	}, client.ForceOwnership)
+// applyResources applies the StatefulSet and Service of a Model that doesn't reference an OllamaServer and expands its volumes.
+func (r *Reconciler) applyResources(ctx context.Context, model *ollamav1alpha1.Model) error {
+	resources, err := Resources(model, r.operator)
+	if err != nil {
+		return fmt.Errorf("while creating resources: %s", err)
+	}
+	// the auth proxy and the blob server mount the Secrets, they're applied before the StatefulSet
+	if err := r.applyAPIKeys(ctx, model); err != nil {
+		return err
+	}
+	if err := r.applyBlobServerSecret(ctx, model); err != nil {
+		return err
+	}
+
+	existingSts := &appsv1.StatefulSet{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(model), existingSts); client.IgnoreNotFound(err) != nil {
+		return errors.Wrap(err, "failed to fetch statefulset")
+	}
+
+	_, typedServerEnv, err := serverEnv(model)
+	if err != nil {
+		return err
+	}
+	for _, res := range resources {
+		if res.GetKind() == "StatefulSet" {
+			conflicts, err := serverConfigConflicts(typedServerEnv, res)
+			if err != nil {
+				return fmt.Errorf("while checking server configuration: %s", err)
+			}
+			setServerConfiguredCondition(model, conflicts)
+		}
+		if res.GetKind() == "StatefulSet" && existingSts.GetUID() != "" {
+			if err := KeepVolumeClaimTemplateSize(res, existingSts); err != nil {
+				return fmt.Errorf("while preserving volume claim templates: %s", err)
+			}
+		}
+		ctrl.LoggerFrom(ctx).V(1).Info("Applying object", "object", res)
+		if err := r.apply(ctx, res); err != nil {
+			return fmt.Errorf("while applying %s %s: %s", res.GetKind(), res.GetName(), err)
+		}
+	}
+
+	if err := PruneNetworkPolicy(ctx, r.client, model, model.Spec.NetworkPolicy); err != nil {
+		return err
+	}
+	return r.expandVolumes(ctx, model)
 }
 
-type applyPatch struct {
-	obj any
+// ollamaModelDetails converts the details of the model returned by Ollama's show API.
+func ollamaModelDetails(resp *ollamaapi.ShowResponse) *ollamav1alpha1.OllamaModelDetails {
+	details := &ollamav1alpha1.OllamaModelDetails{
+		ParameterSize:     resp.Details.ParameterSize,
+		QuantizationLevel: resp.Details.QuantizationLevel,
+		ParentModel:       resp.Details.ParentModel,
+		Format:            resp.Details.Format,
+		Family:            resp.Details.Family,
+		Families:          resp.Details.Families,
+		License:           resp.License,
+		Template:          resp.Template,
+		System:            resp.System,
+		Parameters:        resp.Parameters,
+	}
+	for _, capability := range resp.Capabilities {
+		details.Capabilities = append(details.Capabilities, capability.String())
+	}
+	// model info keys are prefixed with the architecture, e.g. llama.context_length
+	if arch, ok := resp.ModelInfo["general.architecture"].(string); ok {
+		details.Architecture = arch
+		switch contextLength := resp.ModelInfo[arch+".context_length"].(type) {
+		case float64:
+			details.ContextLength = int64(contextLength)
+		case int64:
+			details.ContextLength = contextLength
+		case int:
+			details.ContextLength = int64(contextLength)
+		}
+	}
+	return details
 }
 
-// Data implements client.Patch.
-func (a applyPatch) Data(obj client.Object) ([]byte, error) {
-	return json.Marshal(a.obj)
+// listOllamaPods returns running pods of the Model's StatefulSet, sorted by name.
+func (r *Reconciler) listOllamaPods(ctx context.Context, model *ollamav1alpha1.Model) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := r.client.List(ctx, podList, client.InNamespace(model.GetNamespace()), client.MatchingLabels(podLabels(model))); err != nil {
+		return nil, errors.Wrap(err, "failed to list ollama pods")
+	}
+	pods := slices.DeleteFunc(podList.Items, func(pod corev1.Pod) bool {
+		return pod.GetDeletionTimestamp() != nil || pod.Status.PodIP == ""
+	})
+	slices.SortFunc(pods, func(a, b corev1.Pod) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return pods, nil
 }
 
-// Type implements client.Patch.
-func (a applyPatch) Type() types.PatchType {
-	return types.ApplyPatchType
+// replicaStatus reports which of the desired models are present on the pod.
+func replicaStatus(pod *corev1.Pod, present []string, desired []ollamav1alpha1.ModelEntry) ollamav1alpha1.ReplicaStatus {
+	status := ollamav1alpha1.ReplicaStatus{Pod: pod.GetName()}
+	var missing []string
+	for _, entry := range desired {
+		if slices.Contains(present, entry.Name) {
+			status.Models = append(status.Models, entry.Name)
+		} else {
+			missing = append(missing, entry.Name)
+		}
+	}
+
+	switch {
+	case !podutils.IsPodReady(pod):
+		status.Message = "Pod is not ready"
+	case len(missing) > 0:
+		status.Message = fmt.Sprintf("Missing models: %s", strings.Join(missing, ", "))
+	default:
+		status.Ready = true
+	}
+	return status
 }
 
-func isStatefulSetReady(sts *appsv1.StatefulSet) (string, bool, error) {
+// updateModelStatuses derives state of every desired model, the number of ready replicas and pulled models from replica statuses.
+func updateModelStatuses(model *ollamav1alpha1.Model, desired []ollamav1alpha1.ModelEntry) {
+	model.Status.Models = pruneModelStatuses(model.Status.Models, desired)
+	for _, entry := range desired {
+		entryStatus := setModelStatus(model, ollamav1alpha1.ModelEntryStatus{Name: entry.Name, PullState: ollamav1alpha1.PullStatePending})
+		entryStatus.Registry = resolvedRegistry(entry.Name, insecureRegistry(model))
+		onAllReplicas := len(model.Status.Replicas) > 0 && !slices.ContainsFunc(model.Status.Replicas, func(replica ollamav1alpha1.ReplicaStatus) bool {
+			return !slices.Contains(replica.Models, entry.Name)
+		})
+		switch {
+		case onAllReplicas:
+			if entry.Create == nil && !slices.Contains(model.Status.PulledModels, entry.Name) && seededBeforePull(model, entryStatus) {
+				entryStatus.SeededFrom = seedSource(model)
+			}
+			entryStatus.PullState = ollamav1alpha1.PullStatePulled
+		case entryStatus.PullState == ollamav1alpha1.PullStatePulled:
+			entryStatus.PullState = ollamav1alpha1.PullStatePending
+			entryStatus.Ready = false
+		default:
+			entryStatus.Ready = false
+		}
+	}
+
+	model.Status.ReadyReplicas = 0
+	for _, replica := range model.Status.Replicas {
+		if replica.Ready {
+			model.Status.ReadyReplicas++
+		}
+		for _, name := range replica.Models {
+			if entryStatus := model.ModelStatusFor(name); entryStatus != nil && entryStatus.SeededFrom != "" {
+				continue
+			}
+			if !slices.Contains(model.Status.PulledModels, name) {
+				model.Status.PulledModels = append(model.Status.PulledModels, name)
+			}
+		}
+	}
+}
+
+type pullOutcome int
+
+const (
+	pullInProgress pullOutcome = iota
+	pullSucceeded
+	pullBackingOff
+	pullStalled
+)
+
+// observePull starts pulling the model in the background, or creating it if create is set, unless the previous attempt
+// failed recently, or checks the result of the already running pull.
+func (r *Reconciler) observePull(ctx context.Context, pod *corev1.Pod, model *ollamav1alpha1.Model, key pullKey, create *modelCreate) pullOutcome {
+	log := ctrl.LoggerFrom(ctx).WithValues("model", key.modelName, "pod", key.pod)
+	recorder := r.eventRecorderFor(model)
+	entryStatus := model.ModelStatusFor(key.modelName)
+
+	p := r.pulls.get(key)
+	if p == nil {
+		switch {
+		case entryStatus.FailureReason == ollamav1alpha1.PullFailureModelNotFound || entryStatus.FailureReason == ollamav1alpha1.PullFailureDiskFull ||
+			entryStatus.FailureReason == ollamav1alpha1.PullFailureDigestMismatch:
+			return pullStalled
+		case entryStatus.NextPullAttemptTime != nil && r.timeNowFn().Before(entryStatus.NextPullAttemptTime.Time):
+			if entryStatus.FailureReason != "" {
+				return pullStalled
+			}
+			return pullBackingOff
+		}
+
+		ollamaCli := r.ollamaClientFor(model, pod)
+		if create != nil {
+			log.V(1).Info("started creating ollama model", "from", create.req.From, "blobs", len(create.blobs))
+			recorder.NormalEventf("CreatingModel", "CreatingModel", "Creating %q model on pod %q", key.modelName, key.pod)
+			p = r.pulls.start(ctx, key, createModel(ollamaCli, create, r.blobOpenerFor(model, pod)))
+		} else {
+			log.V(1).Info("started pulling ollama model")
+			recorder.NormalEventf("PullingModel", "PullingModel", "Pulling %q model on pod %q", key.modelName, key.pod)
+			p = r.pulls.start(ctx, key, pullModel(ollamaCli, key.modelName, insecureRegistry(model)))
+		}
+		entryStatus.PullState = ollamav1alpha1.PullStatePulling
+		progress := p.progress()
+		model.Status.Pull = &progress
+		return pullInProgress
+	}
+
+	progress := p.progress()
+	model.Status.Pull = &progress
+
+	finished, status, err := p.result()
+	if !finished {
+		entryStatus.PullState = ollamav1alpha1.PullStatePulling
+		return pullInProgress
+	}
+	r.pulls.forget(key)
+	if err == nil && status != "success" {
+		err = fmt.Errorf("pull finished with %q status", status)
+	}
+	if err == nil {
+		log.V(1).Info("pulled model")
+		resetPullFailure(entryStatus)
+		return pullSucceeded
+	}
+
+	log.V(1).Info("failed to pull model", "error", err.Error())
+	recorder.WarningEventf("PullingModel", "PullingModel", "failed to pull %q model on pod %q: %s", key.modelName, key.pod, err)
+	entryStatus.PullState = ollamav1alpha1.PullStateFailed
+	entryStatus.PullAttempts++
+	entryStatus.LastPullError = err.Error()
+	entryStatus.FailureReason = classifyPullFailure(err)
+	entryStatus.FailedGeneration = model.GetGeneration()
+	entryStatus.NextPullAttemptTime = nil
+
+	switch entryStatus.FailureReason {
+	case "":
+		backoff := pullBackoff(entryStatus.PullAttempts)
+		entryStatus.NextPullAttemptTime = ptr.To(metav1.NewTime(r.timeNowFn().Add(backoff)))
+		entryStatus.Message = fmt.Sprintf("Attempt %d failed, retrying in %s", entryStatus.PullAttempts, backoff)
+		return pullBackingOff
+	case ollamav1alpha1.PullFailureRegistryUnreachable:
+		entryStatus.NextPullAttemptTime = ptr.To(metav1.NewTime(r.timeNowFn().Add(pullBackoffMax)))
+		entryStatus.Message = fmt.Sprintf("Registry is unreachable, retrying in %s", pullBackoffMax)
+	default:
+		entryStatus.Message = fmt.Sprintf("%s, update the spec to retry", entryStatus.FailureReason)
+	}
+	return pullStalled
+}
+
+// resetPullFailure forgets the previous pull failures of the model.
+func resetPullFailure(entryStatus *ollamav1alpha1.ModelEntryStatus) {
+	entryStatus.PullAttempts = 0
+	entryStatus.LastPullError = ""
+	entryStatus.FailureReason = ""
+	entryStatus.FailedGeneration = 0
+	entryStatus.NextPullAttemptTime = nil
+	if entryStatus.PullState == ollamav1alpha1.PullStateFailed {
+		entryStatus.PullState = ollamav1alpha1.PullStatePending
+		entryStatus.Message = ""
+	}
+}
+
+// setStalledCondition reports the first stalled model in the Stalled condition, or marks the Model as progressing again.
+func (r *Reconciler) setStalledCondition(model *ollamav1alpha1.Model, stalled []string) {
+	if len(stalled) == 0 {
+		if model.GetCondition(ollamav1alpha1.TypeStalled).Status == corev1.ConditionTrue {
+			model.SetConditionsWithObservedGeneration(xpv2.Condition{
+				Type:               ollamav1alpha1.TypeStalled,
+				Status:             corev1.ConditionFalse,
+				LastTransitionTime: metav1.Now(),
+				Reason:             ollamav1alpha1.ReasonProgressing,
+			})
+		}
+		return
+	}
+
+	entryStatus := model.ModelStatusFor(stalled[0])
+	model.SetConditionsWithObservedGeneration(xpv2.Condition{
+		Type:               ollamav1alpha1.TypeStalled,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             xpv2.ConditionReason(entryStatus.FailureReason),
+		Message:            fmt.Sprintf("Failed to pull %q model: %s", entryStatus.Name, entryStatus.LastPullError),
+	})
+}
+
+// nextPullAttemptIn returns the time until the earliest retry of given failed models,
+// or zero if none of them is retried automatically.
+func (r *Reconciler) nextPullAttemptIn(model *ollamav1alpha1.Model, failed []string) time.Duration {
+	var next time.Duration
+	for _, name := range failed {
+		entryStatus := model.ModelStatusFor(name)
+		if entryStatus.NextPullAttemptTime == nil {
+			continue
+		}
+		in := max(entryStatus.NextPullAttemptTime.Sub(r.timeNowFn()), time.Second)
+		if next == 0 || in < next {
+			next = in
+		}
+	}
+	return next
+}
+
+func appendIfMissing(s []string, v string) []string {
+	if slices.Contains(s, v) {
+		return s
+	}
+	return append(s, v)
+}
+
+// setModelStatus upserts status of a single model, keeping already reported fields, and returns a pointer to it.
+func setModelStatus(model *ollamav1alpha1.Model, status ollamav1alpha1.ModelEntryStatus) *ollamav1alpha1.ModelEntryStatus {
+	if existing := model.ModelStatusFor(status.Name); existing != nil {
+		return existing
+	}
+	model.Status.Models = append(model.Status.Models, status)
+	return &model.Status.Models[len(model.Status.Models)-1]
+}
+
+// pruneModelStatuses drops statuses of models that are no longer desired and orders the rest like in spec.
+func pruneModelStatuses(statuses []ollamav1alpha1.ModelEntryStatus, desired []ollamav1alpha1.ModelEntry) []ollamav1alpha1.ModelEntryStatus {
+	out := make([]ollamav1alpha1.ModelEntryStatus, 0, len(desired))
+	for _, entry := range desired {
+		idx := slices.IndexFunc(statuses, func(s ollamav1alpha1.ModelEntryStatus) bool { return s.Name == entry.Name })
+		if idx >= 0 {
+			out = append(out, statuses[idx])
+		}
+	}
+	return out
+}
+
+// IsStatefulSetReady reports whether the rollout of the StatefulSet is complete, with a message like the one of kubectl rollout status.
+func IsStatefulSetReady(sts *appsv1.StatefulSet) (string, bool, error) {
 	unstr, err := k8sutils.ToUnstructured(sts)
 	if err != nil {
 		return "", false, err
@@ -247,19 +752,30 @@ func isStatefulSetReady(sts *appsv1.StatefulSet) (string, bool, error) {
 	return strings.TrimSuffix(msg, "...\n"), ready, nil
 }
 
-func newReconciler(cli client.Client, recorder events.EventRecorder, baseHTTPClient *http.Client, tp trace.TracerProvider) *Reconciler {
+func newReconciler(cli client.Client, apiReader client.Reader, recorder events.EventRecorder, baseHTTPClient *http.Client, tp trace.TracerProvider, operator Operator) *Reconciler {
 	return &Reconciler{
+		operator:             operator,
 		client:               cli,
+		apiReader:            apiReader,
 		recorder:             recorder,
 		baseHTTPClient:       baseHTTPClient,
 		ollamaClientProvider: ollamaclient.NewProvider(baseHTTPClient, tp.Tracer("ollama-client")),
 		tp:                   tp,
+		pulls:                newPullManager(time.Now),
 		timeNowFn:            time.Now,
 	}
 }
 
-func SetupWithManager(mgr ctrl.Manager, baseHTTPClient *http.Client, tp trace.TracerProvider) error {
-	r := newReconciler(mgr.GetClient(), mgr.GetEventRecorder("ollama-operator.model-controller"), baseHTTPClient, tp)
+func enqueueModelFromLabel[T client.Object](_ context.Context, obj T) []reconcile.Request {
+	modelName, ok := obj.GetLabels()[modelLabelKey]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: modelName}}}
+}
+
+func SetupWithManager(mgr ctrl.Manager, baseHTTPClient *http.Client, tp trace.TracerProvider, operator Operator) error {
+	r := newReconciler(mgr.GetClient(), mgr.GetAPIReader(), mgr.GetEventRecorder("ollama-operator.model-controller"), baseHTTPClient, tp, operator)
 	reconciler := reconcile.AsReconciler(mgr.GetClient(), r)
 	reconciler = utilreconcilers.NewWithTracingReconciler(
 		reconciler,
@@ -267,10 +783,50 @@ func SetupWithManager(mgr ctrl.Manager, baseHTTPClient *http.Client, tp trace.Tr
 	)
 	reconciler = utilreconcilers.RequeueOnConflict(reconciler)
 
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := mgr.Add(r.pulls); err != nil {
+		return err
+	}
+
+	_, err := mgr.GetRESTMapper().RESTMapping(HTTPRouteGroupVersionKind.GroupKind(), HTTPRouteGroupVersionKind.Version)
+	if err != nil && !meta.IsNoMatchError(err) {
+		return errors.Wrap(err, "failed to check whether Gateway API is installed")
+	}
+	r.gatewayAPIInstalled = err == nil
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&ollamav1alpha1.Model{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Service{}).
+		Owns(&networkingv1.Ingress{}).
+		Owns(&networkingv1.NetworkPolicy{})
+	if r.gatewayAPIInstalled {
+		httpRoute := &unstructured.Unstructured{}
+		httpRoute.SetGroupVersionKind(HTTPRouteGroupVersionKind)
+		b = b.Owns(httpRoute)
+	}
+	return b.
+		// pods and PVCs are owned by the StatefulSet, map them back to the Model using the label
+		WatchesRawSource(source.Kind(mgr.GetCache(), &corev1.Pod{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, pod *corev1.Pod) []reconcile.Request {
+			if serverName, ok := pod.GetLabels()[serverLabelKey]; ok {
+				return r.enqueueModelsOnServer(ctx, pod.GetNamespace(), serverName)
+			}
+			return enqueueModelFromLabel(ctx, pod)
+		}))).
+		WatchesRawSource(source.Kind(mgr.GetCache(), &corev1.PersistentVolumeClaim{}, handler.TypedEnqueueRequestsFromMapFunc(enqueueModelFromLabel[*corev1.PersistentVolumeClaim]))).
+		WatchesRawSource(source.Kind(mgr.GetCache(), &ollamav1alpha1.OllamaServer{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, server *ollamav1alpha1.OllamaServer) []reconcile.Request {
+			return r.enqueueModelsOnServer(ctx, server.GetNamespace(), server.GetName())
+		}))).
+		// finished background pulls
+		WatchesRawSource(source.Channel(r.pulls.events, &handler.TypedEnqueueRequestForObject[*ollamav1alpha1.Model]{})).
+		// Models without finalizers are gone before they are reconciled again, cancel their pulls and forget their state right away
+		WatchesRawSource(source.Kind(mgr.GetCache(), &ollamav1alpha1.Model{}, handler.TypedFuncs[*ollamav1alpha1.Model, reconcile.Request]{
+			DeleteFunc: func(_ context.Context, e event.TypedDeleteEvent[*ollamav1alpha1.Model], _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				key := client.ObjectKeyFromObject(e.Object)
+				r.pulls.cancelStale(key, func(pullKey) bool { return false })
+				r.creates.Delete(key)
+				r.blobServerTokens.Delete(key)
+			},
+		})).
 		WithLogConstructor(func(req *reconcile.Request) logr.Logger {
 			log := mgr.GetLogger().WithValues("controller", "model-controller")
 			if req == nil {
@@ -281,37 +837,77 @@ func SetupWithManager(mgr ctrl.Manager, baseHTTPClient *http.Client, tp trace.Tr
 		Complete(reconciler)
 }
 
-func Resources(model *ollamav1alpha1.Model) ([]*unstructured.Unstructured, error) {
-	labels := commonmeta.LabelsForResource(model.GetName(), map[string]string{
-		"ollama.aerf.io/model": model.GetName(),
+const modelLabelKey = "ollama.aerf.io/model"
+
+func volumeClaimTemplate(model *ollamav1alpha1.Model) *applycorev1.PersistentVolumeClaimApplyConfiguration {
+	accessModes := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	var storageClassName *string
+	if model.Spec.Storage != nil {
+		if len(model.Spec.Storage.AccessModes) > 0 {
+			accessModes = model.Spec.Storage.AccessModes
+		}
+		storageClassName = model.Spec.Storage.StorageClassName
+	}
+
+	spec := applycorev1.PersistentVolumeClaimSpec().
+		WithAccessModes(accessModes...).
+		WithResources(
+			applycorev1.VolumeResourceRequirements().
+				WithRequests(
+					corev1.ResourceList{
+						corev1.ResourceStorage: desiredStorageSize(model),
+					},
+				),
+		)
+	if storageClassName != nil {
+		spec.WithStorageClassName(*storageClassName)
+	}
+	if dataSource := seedDataSource(model); dataSource != nil {
+		spec.WithDataSource(dataSource)
+	}
+	return applycorev1.PersistentVolumeClaim(storageVolumeName(model), model.GetNamespace()).WithSpec(spec)
+}
+
+// podLabels returns labels of the Ollama pods serving the Model, which are the ones of the OllamaServer if it references one.
+func podLabels(model *ollamav1alpha1.Model) map[string]string {
+	if model.Spec.ServerRef != nil {
+		return serverPodLabels(model.Spec.ServerRef.Name)
+	}
+	return commonmeta.LabelsForResource(model.GetName(), map[string]string{
+		modelLabelKey: model.GetName(),
 	})
+}
+
+// Resources returns the StatefulSet and Service running Ollama for the Model, and its NetworkPolicy if spec.networkPolicy is set.
+func Resources(model *ollamav1alpha1.Model, operator Operator) ([]*unstructured.Unstructured, error) {
+	return resources(model, model, podLabels(model), operator)
+}
+
+// resources renders the StatefulSet, Service and NetworkPolicy from the spec of the Model, owned by the owner.
+func resources(model *ollamav1alpha1.Model, owner client.Object, labels map[string]string, operator Operator) ([]*unstructured.Unstructured, error) {
 	httpAPIPortName := "http-api"
-	containerName := "ollama"
+	containerName := ollamaContainerName
+	env, _, err := serverEnv(model)
+	if err != nil {
+		return nil, err
+	}
+	envApplyConfigs := make([]*applycorev1.EnvVarApplyConfiguration, 0, len(env))
+	for _, e := range env {
+		envApplyConfigs = append(envApplyConfigs, applycorev1.EnvVar().WithName(e.Name).WithValue(e.Value))
+	}
+	if model.Spec.Auth != nil {
+		envApplyConfigs = append(envApplyConfigs, authProxyUpstreamEnv())
+	}
 	sts := applyappsv1.StatefulSet(model.GetName(), model.GetNamespace()).
 		WithLabels(labels).
 		WithOwnerReferences(
-			applyconfig.ControllerReferenceFrom(model)).
+			applyconfig.ControllerReferenceFrom(owner)).
 		WithSpec(
 			applyappsv1.StatefulSetSpec().
 				WithSelector(applymetav1.LabelSelector().WithMatchLabels(labels)).
 				WithServiceName(model.GetName()).
-				WithReplicas(1). // do NOT adapt this field, the current logic only allows for replicas=1
-				WithMinReadySeconds(10).
-				WithVolumeClaimTemplates(
-					applycorev1.PersistentVolumeClaim(model.GetName()+"-ollama-root", model.GetNamespace()).
-						WithSpec(
-							applycorev1.PersistentVolumeClaimSpec().
-								WithAccessModes(corev1.ReadWriteOnce).
-								WithResources(
-									applycorev1.VolumeResourceRequirements().
-										WithRequests(
-											corev1.ResourceList{
-												corev1.ResourceStorage: apimachineryresource.MustParse("20Gi"),
-											},
-										),
-								),
-						),
-				).WithTemplate(
+				WithReplicas(desiredReplicas(model)).
+				WithMinReadySeconds(10).WithTemplate(
 				applycorev1.PodTemplateSpec().
 					WithLabels(labels).
 					WithAnnotations(map[string]string{
@@ -329,11 +925,7 @@ func Resources(model *ollamav1alpha1.Model) ([]*unstructured.Unstructured, error
 										WithContainerPort(defaults.OllamaPort).
 										WithProtocol(corev1.ProtocolTCP),
 								).
-								WithEnv(
-									applycorev1.EnvVar().WithName("OLLAMA_KEEP_ALIVE").WithValue("-1"), // infinity
-									applycorev1.EnvVar().WithName("OLLAMA_MAX_LOADED_MODELS").WithValue("1"),
-									applycorev1.EnvVar().WithName("OLLAMA_DEBUG").WithValue("false"),
-								).
+								WithEnv(envApplyConfigs...).
 								WithLivenessProbe(
 									applycorev1.Probe().
 										WithInitialDelaySeconds(10).
@@ -358,17 +950,56 @@ func Resources(model *ollamav1alpha1.Model) ([]*unstructured.Unstructured, error
 								).
 								WithVolumeMounts(
 									applycorev1.VolumeMount().
-										WithName(model.GetName() + "-ollama-root").
+										WithName(storageVolumeName(model)).
 										WithMountPath("/root/.ollama"),
 								),
 						),
 					),
 			),
 		)
+	if container, volumes := blobServer(model); container != nil {
+		sts.Spec.Template.Spec.WithContainers(container).WithVolumes(volumes...)
+	}
+	if container, volume := authProxy(model, operator); container != nil {
+		// the proxy takes over the Ollama port, so the Service, probes and the operator go through it
+		sts.Spec.Template.Spec.WithContainers(container).WithVolumes(volume)
+	}
+	if volume, mounts := registryCredentialsVolume(model); volume != nil {
+		sts.Spec.Template.Spec.WithVolumes(volume)
+		sts.Spec.Template.Spec.Containers[0].WithVolumeMounts(mounts...)
+	}
+	if volume, mount := seedImageVolume(model); volume != nil {
+		sts.Spec.Template.Spec.WithVolumes(volume)
+		sts.Spec.Template.Spec.Containers[0].WithVolumeMounts(mount).WithEnv(noPruneEnv())
+	}
+	if usesEmptyDir(model) {
+		emptyDir := model.Spec.Storage.EmptyDir
+		emptyDirVolume := applycorev1.EmptyDirVolumeSource()
+		if emptyDir.Medium != "" {
+			emptyDirVolume.WithMedium(emptyDir.Medium)
+		}
+		if emptyDir.SizeLimit != nil {
+			emptyDirVolume.WithSizeLimit(*emptyDir.SizeLimit)
+		}
+		sts.Spec.Template.Spec.WithVolumes(
+			applycorev1.Volume().
+				WithName(storageVolumeName(model)).
+				WithEmptyDir(emptyDirVolume),
+		)
+	} else {
+		sts.Spec.WithVolumeClaimTemplates(volumeClaimTemplate(model))
+		if model.Spec.Storage != nil && model.Spec.Storage.RetentionPolicy != "" {
+			sts.Spec.WithPersistentVolumeClaimRetentionPolicy(applyappsv1.StatefulSetPersistentVolumeClaimRetentionPolicy().
+				WithWhenDeleted(appsv1.PersistentVolumeClaimRetentionPolicyType(model.Spec.Storage.RetentionPolicy)).
+				// scaling to zero with spec.idle or spec.suspend keeps the pulled models
+				WithWhenScaled(appsv1.RetainPersistentVolumeClaimRetentionPolicyType),
+			)
+		}
+	}
 
 	svc := applycorev1.Service(model.GetName(), model.GetNamespace()).
 		WithLabels(labels).
-		WithOwnerReferences(applyconfig.ControllerReferenceFrom(model)).
+		WithOwnerReferences(applyconfig.ControllerReferenceFrom(owner)).
 		WithSpec(
 			applycorev1.ServiceSpec().
 				WithType(corev1.ServiceTypeClusterIP).
@@ -382,6 +1013,10 @@ func Resources(model *ollamav1alpha1.Model) ([]*unstructured.Unstructured, error
 				WithSelector(labels),
 		)
 
+	if container := pullInitContainer(model, &sts.Spec.Template.Spec.Containers[0]); container != nil {
+		sts.Spec.Template.Spec.WithInitContainers(container)
+	}
+
 	patchedSts, err := patches.Apply(sts, model.Spec.StatefulSetPatches)
 	if err != nil {
 		return nil, err
@@ -400,8 +1035,24 @@ func Resources(model *ollamav1alpha1.Model) ([]*unstructured.Unstructured, error
 		return nil, err
 	}
 
-	return []*unstructured.Unstructured{
+	out := []*unstructured.Unstructured{
 		unstructuredSts,
 		unstructuredSvc,
-	}, nil
+	}
+	netpol, err := networkPolicy(model, owner, labels, operator)
+	if err != nil {
+		return nil, err
+	}
+	if netpol != nil {
+		patchedNetpol, err := patches.Apply(netpol, model.Spec.NetworkPolicy.Patches)
+		if err != nil {
+			return nil, err
+		}
+		unstructuredNetpol, err := k8sutils.ToUnstructured(patchedNetpol)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, unstructuredNetpol)
+	}
+	return out, nil
 }
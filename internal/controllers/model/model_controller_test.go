@@ -4,19 +4,24 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/go-logr/logr/testr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/ollama/ollama/api"
+	ollamamodel "github.com/ollama/ollama/types/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/trace/noop"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -25,6 +30,7 @@ import (
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -85,7 +91,7 @@ func Test_isStatefulSetReady(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			msg, ready, err := isStatefulSetReady(tt.sts)
+			msg, ready, err := IsStatefulSetReady(tt.sts)
 			require.NoError(t, err) // should never err
 			assert.Equal(t, tt.ready, ready, msg)
 			if tt.msgContains != "" {
@@ -95,6 +101,84 @@ func Test_isStatefulSetReady(t *testing.T) {
 	}
 }
 
+func Test_pruneModelStatuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []ollamav1alpha1.ModelEntryStatus
+		desired  []ollamav1alpha1.ModelEntry
+		want     []ollamav1alpha1.ModelEntryStatus
+	}{
+		{
+			name:     "no statuses yet",
+			statuses: nil,
+			desired:  []ollamav1alpha1.ModelEntry{{Name: "phi3"}},
+			want:     []ollamav1alpha1.ModelEntryStatus{},
+		},
+		{
+			name: "drops statuses of models that are no longer desired and follows desired order",
+			statuses: []ollamav1alpha1.ModelEntryStatus{
+				{Name: "phi3", Ready: true},
+				{Name: "llama3.1", Ready: true},
+				{Name: "gemma2", PullState: ollamav1alpha1.PullStatePulling},
+			},
+			desired: []ollamav1alpha1.ModelEntry{{Name: "gemma2"}, {Name: "phi3"}},
+			want: []ollamav1alpha1.ModelEntryStatus{
+				{Name: "gemma2", PullState: ollamav1alpha1.PullStatePulling},
+				{Name: "phi3", Ready: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pruneModelStatuses(tt.statuses, tt.desired)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("pruneModelStatuses() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ollamaModelDetails(t *testing.T) {
+	resp := &api.ShowResponse{
+		License:    "MIT",
+		Template:   "{{ .Prompt }}",
+		System:     "You are a helpful assistant.",
+		Parameters: "stop \"<|end|>\"",
+		Details: api.ModelDetails{
+			Format:            "gguf",
+			Family:            "llama",
+			Families:          []string{"llama"},
+			ParameterSize:     "8.0B",
+			QuantizationLevel: "Q4_K_M",
+		},
+		ModelInfo: map[string]any{
+			"general.architecture": "llama",
+			"llama.context_length": float64(131072),
+		},
+		Capabilities: []ollamamodel.Capability{ollamamodel.CapabilityCompletion, ollamamodel.CapabilityTools},
+	}
+	want := &ollamav1alpha1.OllamaModelDetails{
+		ParameterSize:     "8.0B",
+		QuantizationLevel: "Q4_K_M",
+		Format:            "gguf",
+		Family:            "llama",
+		Families:          []string{"llama"},
+		Capabilities:      []string{"completion", "tools"},
+		Architecture:      "llama",
+		ContextLength:     131072,
+		License:           "MIT",
+		Template:          "{{ .Prompt }}",
+		System:            "You are a helpful assistant.",
+		Parameters:        "stop \"<|end|>\"",
+	}
+	details := ollamaModelDetails(resp)
+	if diff := cmp.Diff(want, details); diff != "" {
+		t.Errorf("ollamaModelDetails() mismatch (-want +got):\n%s", diff)
+	}
+	assert.True(t, details.HasCapability("tools"))
+	assert.False(t, details.HasCapability("vision"))
+}
+
 // test apiserver does not return GVK inside the struct, what the hell
 type addGVKReconciler struct {
 	inner reconcile.ObjectReconciler[*ollamav1alpha1.Model]
@@ -144,6 +228,7 @@ func TestReconciler_Reconcile(t *testing.T) {
 					recorder:       record.NewEventRecorderAdapter(record.NewFakeRecorder(1000)),
 					baseHTTPClient: &http.Client{},
 					tp:             noop.NewTracerProvider(),
+					pulls:          newPullManager(time.Now),
 					ollamaClientProvider: &ollamaclient.TestOllamaClientProvider{
 						Client: &ollamaclient.TestOllamaClient{
 							OnList: func(ctx context.Context) (*api.ListResponse, error) {
@@ -167,6 +252,9 @@ func TestReconciler_Reconcile(t *testing.T) {
 									}, nil
 								}
 							},
+							OnPull: func(ctx context.Context, req *api.PullRequest, progressFunc api.PullProgressFunc) error {
+								return progressFunc(api.ProgressResponse{Status: "success"})
+							},
 							OnShow: func(ctx context.Context, req *api.ShowRequest) (*api.ShowResponse, error) {
 								defer func() {
 									showCallNumber += 1
@@ -187,6 +275,9 @@ func TestReconciler_Reconcile(t *testing.T) {
 									}, nil
 								}
 							},
+							OnListRunning: func(ctx context.Context) (*api.ProcessResponse, error) {
+								return &api.ProcessResponse{}, nil
+							},
 						},
 					},
 				},
@@ -236,6 +327,22 @@ func TestReconciler_Reconcile(t *testing.T) {
 			return cli.Status().Update(context.Background(), sts)
 		})
 		require.NoError(t, err)
+
+		// there's no kubelet in envtest, create the pod of the statefulset ourselves
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      model.GetName() + "-0",
+				Namespace: model.GetNamespace(),
+				Labels:    podLabels(model),
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "ollama", Image: "ollama"}},
+			},
+		}
+		require.NoError(t, cli.Create(context.Background(), pod))
+		pod.Status.PodIP = "10.0.0.1"
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		require.NoError(t, cli.Status().Update(context.Background(), pod))
 		err = reconcileFn()
 		require.Error(t, err, "error must be non-nil: there should be an error from failed list call")
 
@@ -269,5 +376,156 @@ func TestReconciler_Reconcile(t *testing.T) {
 		if diff := cmp.Diff(mdl.GetCondition(xpv2.TypeReady), xpv2.Available(), testutils.IgnoreXPv1ConditionFields()); diff != "" {
 			t.Fatalf("conditions differ, -got +want:\n%s", diff)
 		}
+		require.Equal(t, int32(1), mdl.Status.ReadyReplicas)
+		require.Equal(t, []ollamav1alpha1.ReplicaStatus{{Pod: pod.GetName(), Ready: true, Models: []string{model.Spec.Model}}}, mdl.Status.Replicas)
 	})
 }
+
+// statefulSetResource renders the resources of the Model and returns its StatefulSet, which is always the first one.
+func statefulSetResource(t *testing.T, model *ollamav1alpha1.Model, operator Operator) *unstructured.Unstructured {
+	t.Helper()
+	resources, err := Resources(model, operator)
+	require.NoError(t, err)
+	return resources[0]
+}
+
+// decodeStatefulSet converts the rendered StatefulSet of a Model or an OllamaServer to the typed one.
+func decodeStatefulSet(t *testing.T, res *unstructured.Unstructured) *appsv1.StatefulSet {
+	t.Helper()
+	require.Equal(t, "StatefulSet", res.GetKind())
+	sts := &appsv1.StatefulSet{}
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(res.Object, sts))
+	return sts
+}
+
+// statefulSetOf renders the resources of the Model and returns its typed StatefulSet.
+func statefulSetOf(t *testing.T, model *ollamav1alpha1.Model, operator Operator) *appsv1.StatefulSet {
+	t.Helper()
+	return decodeStatefulSet(t, statefulSetResource(t, model, operator))
+}
+
+// fakeModelCluster is a fake API server with a single Model, whose StatefulSet is ready and has one ready pod,
+// so Reconcile gets to pulling the models. There's no kubelet, tests change the pod or the StatefulSet themselves.
+type fakeModelCluster struct {
+	cli   client.Client
+	r     *Reconciler
+	model client.ObjectKey
+}
+
+func newFakeModelCluster(t *testing.T, model *ollamav1alpha1.Model, ollamaCli *ollamaclient.TestOllamaClient) *fakeModelCluster {
+	t.Helper()
+	s := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(s))
+	require.NoError(t, ollamav1alpha1.AddToScheme(s))
+
+	model.SetGroupVersionKind(ollamav1alpha1.ModelGroupVersionKind)
+	model.SetGeneration(1)
+	sts := statefulSetOf(t, model, Operator{})
+	sts.SetGeneration(1)
+	// defaulted by the API server
+	sts.Spec.UpdateStrategy.Type = appsv1.RollingUpdateStatefulSetStrategyType
+	sts.Status = appsv1.StatefulSetStatus{ObservedGeneration: 1, Replicas: 1, ReadyReplicas: 1, CurrentReplicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: model.GetName() + "-0", Namespace: model.GetNamespace(), Labels: podLabels(model)},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "ollama", Image: "ollama"}}},
+		Status: corev1.PodStatus{
+			PodIP:      "10.0.0.1",
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(model, sts, pod).
+		WithStatusSubresource(&ollamav1alpha1.Model{}, &appsv1.StatefulSet{}, &corev1.Pod{}).
+		Build()
+	r := newReconciler(client.WithFieldOwner(cli, "ollama-operator"), cli, record.NewEventRecorderAdapter(record.NewFakeRecorder(1000)), &http.Client{}, noop.NewTracerProvider(), Operator{})
+	r.ollamaClientProvider = &ollamaclient.TestOllamaClientProvider{Client: ollamaCli}
+	return &fakeModelCluster{cli: cli, r: r, model: client.ObjectKeyFromObject(model)}
+}
+
+// reconcile reconciles the Model and returns it with the updated status.
+func (c *fakeModelCluster) reconcile(t *testing.T) *ollamav1alpha1.Model {
+	t.Helper()
+	model := c.get(t)
+	_, err := c.r.Reconcile(ctrl.LoggerInto(context.Background(), testr.New(t)), model)
+	require.NoError(t, err)
+	return c.get(t)
+}
+
+// reconcileUntilPullFinished reconciles the Model until the pull started in the background finishes.
+func (c *fakeModelCluster) reconcileUntilPullFinished(t *testing.T) *ollamav1alpha1.Model {
+	t.Helper()
+	c.reconcile(t)
+	select {
+	case <-c.r.pulls.events:
+	case <-time.After(10 * time.Second):
+		t.Fatal("pull didn't finish")
+	}
+	return c.reconcile(t)
+}
+
+func (c *fakeModelCluster) get(t *testing.T) *ollamav1alpha1.Model {
+	t.Helper()
+	model := &ollamav1alpha1.Model{}
+	require.NoError(t, c.cli.Get(context.Background(), c.model, model))
+	model.SetGroupVersionKind(ollamav1alpha1.ModelGroupVersionKind)
+	return model
+}
+
+// updateSpec changes the spec of the Model, bumping its generation like the API server.
+func (c *fakeModelCluster) updateSpec(t *testing.T, mutate func(spec *ollamav1alpha1.ModelSpec)) {
+	t.Helper()
+	model := c.get(t)
+	mutate(&model.Spec)
+	model.SetGeneration(model.GetGeneration() + 1)
+	require.NoError(t, c.cli.Update(context.Background(), model))
+}
+
+// setStatefulSetReady marks the StatefulSet as ready or rolling out.
+func (c *fakeModelCluster) setStatefulSetReady(t *testing.T, ready bool) {
+	t.Helper()
+	sts := &appsv1.StatefulSet{}
+	require.NoError(t, c.cli.Get(context.Background(), c.model, sts))
+	sts.Status.ReadyReplicas = 0
+	if ready {
+		sts.Status.ReadyReplicas = 1
+	}
+	require.NoError(t, c.cli.Status().Update(context.Background(), sts))
+}
+
+func TestReconciler_Reconcile_retriesStalledPullsOnSpecChange(t *testing.T) {
+	var pulls atomic.Int32
+	c := newFakeModelCluster(t, &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default"},
+		Spec:       ollamav1alpha1.ModelSpec{Model: "phi3"},
+	}, &ollamaclient.TestOllamaClient{
+		OnList: func(context.Context) (*api.ListResponse, error) { return &api.ListResponse{}, nil },
+		OnPull: func(context.Context, *api.PullRequest, api.PullProgressFunc) error {
+			pulls.Add(1)
+			return errors.New("pull model manifest: file does not exist")
+		},
+		OnListRunning: func(context.Context) (*api.ProcessResponse, error) { return &api.ProcessResponse{}, nil },
+	})
+
+	model := c.reconcileUntilPullFinished(t)
+	entryStatus := model.ModelStatusFor("phi3")
+	require.Equal(t, ollamav1alpha1.PullFailureModelNotFound, entryStatus.FailureReason)
+	require.Equal(t, int64(1), entryStatus.FailedGeneration)
+	require.Equal(t, xpv2.ConditionReason(ollamav1alpha1.PullFailureModelNotFound), model.GetCondition(ollamav1alpha1.TypeStalled).Reason)
+
+	model = c.reconcile(t)
+	require.Equal(t, int32(1), pulls.Load(), "stalled pulls are not retried")
+	require.Equal(t, ollamav1alpha1.PullFailureModelNotFound, model.ModelStatusFor("phi3").FailureReason)
+
+	// the status is updated while the StatefulSet rolls out, before the failed pull is observed again
+	c.updateSpec(t, func(spec *ollamav1alpha1.ModelSpec) { spec.OllamaImage = "ollama/ollama:0.5.0" })
+	c.setStatefulSetReady(t, false)
+	model = c.reconcile(t)
+	require.Equal(t, int64(2), model.Status.ObservedGeneration)
+	require.Equal(t, int32(1), pulls.Load())
+
+	c.setStatefulSetReady(t, true)
+	model = c.reconcileUntilPullFinished(t)
+	require.Equal(t, int32(2), pulls.Load(), "the pull is retried after the spec changed")
+	require.Equal(t, int64(2), model.ModelStatusFor("phi3").FailedGeneration)
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"context"
+	"maps"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	applynetworkingv1 "k8s.io/client-go/applyconfigurations/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/applyconfig"
+	"aerf.io/ollama-operator/internal/defaults"
+)
+
+// Operator describes the operator deployment generated resources depend on. Generated NetworkPolicies always
+// allow its pods, as the operator talks to every Ollama pod directly.
+type Operator struct {
+	// Namespace of the operator, NetworkPolicies can't be generated if it's empty, as the operator rule would allow
+	// pods with PodLabels from any namespace.
+	Namespace string
+	// PodLabels of the operator pods.
+	PodLabels map[string]string
+	// AuthProxyImage is the image of the auth proxy sidecar injected for spec.auth, defaults.AuthProxyImage if it's empty.
+	AuthProxyImage string
+}
+
+func (p Operator) applyConfiguration() (*applynetworkingv1.NetworkPolicyPeerApplyConfiguration, error) {
+	if p.Namespace == "" {
+		return nil, errors.New("namespace of the operator is unknown, set the --operator-namespace flag or the POD_NAMESPACE env var of the operator")
+	}
+	namespaceSelector := applymetav1.LabelSelector().WithMatchLabels(map[string]string{corev1.LabelMetadataName: p.Namespace})
+	podSelector := applymetav1.LabelSelector()
+	if len(p.PodLabels) > 0 {
+		podSelector.WithMatchLabels(maps.Clone(p.PodLabels))
+	}
+	return applynetworkingv1.NetworkPolicyPeer().
+		WithNamespaceSelector(namespaceSelector).
+		WithPodSelector(podSelector), nil
+}
+
+// networkPolicy returns the NetworkPolicy from spec.networkPolicy selecting the Ollama pods, or nil if it's not set.
+// The operator may reach every port, e.g. the one of the blob-server sidecar, other peers only the Ollama API.
+func networkPolicy(model *ollamav1alpha1.Model, owner client.Object, labels map[string]string, operator Operator) (*applynetworkingv1.NetworkPolicyApplyConfiguration, error) {
+	if model.Spec.NetworkPolicy == nil {
+		return nil, nil
+	}
+	operatorPeer, err := operator.applyConfiguration()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't allow the operator in the NetworkPolicy")
+	}
+	spec := applynetworkingv1.NetworkPolicySpec().
+		WithPodSelector(applymetav1.LabelSelector().WithMatchLabels(labels)).
+		WithPolicyTypes(networkingv1.PolicyTypeIngress).
+		WithIngress(applynetworkingv1.NetworkPolicyIngressRule().WithFrom(operatorPeer))
+	if from := model.Spec.NetworkPolicy.From; len(from) > 0 {
+		rule := applynetworkingv1.NetworkPolicyIngressRule().
+			WithPorts(applynetworkingv1.NetworkPolicyPort().
+				WithProtocol(corev1.ProtocolTCP).
+				WithPort(intstr.FromInt32(defaults.OllamaPort)),
+			)
+		for _, peer := range from {
+			rule.WithFrom(applynetworkingv1.NetworkPolicyPeer().
+				WithNamespaceSelector(labelSelectorApplyConfiguration(peer.NamespaceSelector)).
+				WithPodSelector(labelSelectorApplyConfiguration(peer.PodSelector)),
+			)
+		}
+		spec.WithIngress(rule)
+	}
+	return applynetworkingv1.NetworkPolicy(model.GetName(), model.GetNamespace()).
+		WithLabels(labels).
+		WithOwnerReferences(applyconfig.ControllerReferenceFrom(owner)).
+		WithSpec(spec), nil
+}
+
+func labelSelectorApplyConfiguration(selector *metav1.LabelSelector) *applymetav1.LabelSelectorApplyConfiguration {
+	if selector == nil {
+		return nil
+	}
+	ac := applymetav1.LabelSelector().WithMatchLabels(selector.MatchLabels)
+	for _, expr := range selector.MatchExpressions {
+		ac.WithMatchExpressions(applymetav1.LabelSelectorRequirement().
+			WithKey(expr.Key).
+			WithOperator(expr.Operator).
+			WithValues(expr.Values...),
+		)
+	}
+	return ac
+}
+
+// PruneNetworkPolicy deletes the NetworkPolicy of the Model or OllamaServer if spec.networkPolicy was unset.
+func PruneNetworkPolicy(ctx context.Context, cli client.Client, obj client.Object, spec *ollamav1alpha1.NetworkPolicy) error {
+	if spec != nil {
+		return nil
+	}
+	existing := &networkingv1.NetworkPolicy{}
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		return client.IgnoreNotFound(errors.Wrap(err, "failed to fetch networkpolicy"))
+	}
+	if !metav1.IsControlledBy(existing, obj) {
+		return nil
+	}
+	return client.IgnoreNotFound(errors.Wrap(cli.Delete(ctx, existing), "failed to delete networkpolicy"))
+}
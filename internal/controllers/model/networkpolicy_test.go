@@ -0,0 +1,96 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func TestResources_networkPolicy(t *testing.T) {
+	model := &ollamav1alpha1.Model{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ollamav1alpha1.ModelGroupVersionKind.GroupVersion().String(), Kind: ollamav1alpha1.ModelKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default", UID: "uid"},
+		Spec:       ollamav1alpha1.ModelSpec{Model: "phi3"},
+	}
+	operator := Operator{Namespace: "ollama-system", PodLabels: map[string]string{"app.kubernetes.io/name": "ollama-operator"}}
+
+	resources, err := Resources(model, operator)
+	require.NoError(t, err)
+	require.Len(t, resources, 2, "NetworkPolicy is generated only with spec.networkPolicy")
+
+	model.Spec.NetworkPolicy = &ollamav1alpha1.NetworkPolicy{
+		From: []ollamav1alpha1.NetworkPolicyPeer{
+			{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "ingress-nginx"}}},
+			{PodSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"chat"}},
+			}}},
+		},
+		Patches: &ollamav1alpha1.Patches{MergePatch: ollamav1alpha1.MergePatch{MergePatch: &runtime.RawExtension{
+			Raw: []byte(`{"metadata":{"annotations":{"team":"ml"}}}`),
+		}}},
+	}
+	_, err = Resources(model, Operator{PodLabels: operator.PodLabels})
+	require.ErrorContains(t, err, "namespace of the operator is unknown", "the operator rule would match pods from any namespace")
+
+	resources, err = Resources(model, operator)
+	require.NoError(t, err)
+	require.Len(t, resources, 3)
+
+	netpol := &networkingv1.NetworkPolicy{}
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(resources[2].Object, netpol))
+	require.Equal(t, "phi3", netpol.GetName())
+	require.Equal(t, "ml", netpol.GetAnnotations()["team"])
+	require.Equal(t, ollamav1alpha1.ModelKind, netpol.GetOwnerReferences()[0].Kind)
+	require.Equal(t, podLabels(model), netpol.Spec.PodSelector.MatchLabels)
+	require.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, netpol.Spec.PolicyTypes)
+	require.Equal(t, []networkingv1.NetworkPolicyIngressRule{
+		{
+			From: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "ollama-system"}},
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": "ollama-operator"}},
+			}},
+		},
+		{
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(11434))}},
+			From: []networkingv1.NetworkPolicyPeer{
+				{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "ingress-nginx"}}},
+				{PodSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"chat"}},
+				}}},
+			},
+		},
+	}, netpol.Spec.Ingress)
+}
+
+func TestPruneNetworkPolicy(t *testing.T) {
+	model := &ollamav1alpha1.Model{ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default", UID: "uid"}}
+	netpol := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{
+		Name:            "phi3",
+		Namespace:       "default",
+		OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(model, ollamav1alpha1.ModelGroupVersionKind)},
+	}}
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(netpol).Build()
+	ctx := context.Background()
+
+	require.NoError(t, PruneNetworkPolicy(ctx, cli, model, &ollamav1alpha1.NetworkPolicy{}))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(netpol), &networkingv1.NetworkPolicy{}), "kept while spec.networkPolicy is set")
+
+	require.NoError(t, PruneNetworkPolicy(ctx, cli, model, nil))
+	err := cli.Get(ctx, client.ObjectKeyFromObject(netpol), &networkingv1.NetworkPolicy{})
+	require.True(t, apierrors.IsNotFound(err))
+
+	require.NoError(t, PruneNetworkPolicy(ctx, cli, model, nil), "missing NetworkPolicy is fine")
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/commonmeta"
+)
+
+const serverLabelKey = "ollama.aerf.io/server"
+
+func serverPodLabels(serverName string) map[string]string {
+	return commonmeta.LabelsForResource(serverName, map[string]string{
+		serverLabelKey: serverName,
+	})
+}
+
+// ServerResources returns the StatefulSet and Service running Ollama for the OllamaServer, and its NetworkPolicy if spec.networkPolicy is set.
+func ServerResources(server *ollamav1alpha1.OllamaServer, operator Operator) ([]*unstructured.Unstructured, error) {
+	return resources(serverModel(server), server, serverPodLabels(server.GetName()), operator)
+}
+
+// serverModel returns a Model without any models carrying the configuration of the OllamaServer, so that the StatefulSet
+// and Service of the server are rendered the same way as the ones of standalone Models.
+func serverModel(server *ollamav1alpha1.OllamaServer) *ollamav1alpha1.Model {
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: *server.ObjectMeta.DeepCopy(),
+		Spec: ollamav1alpha1.ModelSpec{
+			OllamaImage:        server.Spec.OllamaImage,
+			Replicas:           server.Spec.Replicas,
+			Storage:            server.Spec.Storage,
+			Server:             server.Spec.Server,
+			NetworkPolicy:      server.Spec.NetworkPolicy,
+			StatefulSetPatches: server.Spec.StatefulSetPatches,
+			ServicePatches:     server.Spec.ServicePatches,
+		},
+	}
+	if server.Spec.RegistryCredentialsSecretRef != nil {
+		model.Spec.Registry = &ollamav1alpha1.Registry{CredentialsSecretRef: server.Spec.RegistryCredentialsSecretRef}
+	}
+	return model
+}
+
+// statefulSetName returns the name of the StatefulSet serving the Model.
+func statefulSetName(model *ollamav1alpha1.Model) string {
+	if model.Spec.ServerRef != nil {
+		return model.Spec.ServerRef.Name
+	}
+	return model.GetName()
+}
+
+// modelsOnServer returns names of the models desired by other Models hosted on the same OllamaServer as the Model.
+// They must not be deleted from the server when the Model no longer needs them.
+func (r *Reconciler) modelsOnServer(ctx context.Context, model *ollamav1alpha1.Model) ([]string, error) {
+	if model.Spec.ServerRef == nil {
+		return nil, nil
+	}
+	modelList := &ollamav1alpha1.ModelList{}
+	if err := r.client.List(ctx, modelList, client.InNamespace(model.GetNamespace())); err != nil {
+		return nil, errors.Wrap(err, "failed to list Models")
+	}
+	var names []string
+	for _, other := range modelList.Items {
+		if other.GetName() == model.GetName() || other.GetDeletionTimestamp() != nil ||
+			other.Spec.ServerRef == nil || other.Spec.ServerRef.Name != model.Spec.ServerRef.Name {
+			continue
+		}
+		for _, entry := range other.DesiredModels() {
+			names = appendIfMissing(names, entry.Name)
+		}
+	}
+	return names, nil
+}
+
+// EnqueueServerFromLabel maps pods and PVCs of an OllamaServer back to it using the label.
+func EnqueueServerFromLabel[T client.Object](_ context.Context, obj T) []reconcile.Request {
+	serverName, ok := obj.GetLabels()[serverLabelKey]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: serverName}}}
+}
+
+// enqueueModelsOnServer returns requests for all Models hosted on the OllamaServer.
+func (r *Reconciler) enqueueModelsOnServer(ctx context.Context, namespace, serverName string) []reconcile.Request {
+	modelList := &ollamav1alpha1.ModelList{}
+	if err := r.client.List(ctx, modelList, client.InNamespace(namespace)); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "unable to list Models", "server", serverName)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, model := range modelList.Items {
+		if model.Spec.ServerRef != nil && model.Spec.ServerRef.Name == serverName {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&model)})
+		}
+	}
+	return requests
+}
+
+// checkServerSupport returns an error if the Model uses features that need a dedicated StatefulSet.
+func checkServerSupport(model *ollamav1alpha1.Model) error {
+	if model.Spec.ServerRef == nil {
+		return nil
+	}
+	for _, entry := range model.DesiredModels() {
+		if entry.Create == nil {
+			continue
+		}
+		if slices.ContainsFunc(slices.Concat(entry.Create.Files, entry.Create.Adapters), func(file ollamav1alpha1.ModelFile) bool {
+			return file.PersistentVolumeClaim != nil
+		}) {
+			return fmt.Errorf("model %q imports files from a persistentVolumeClaim, which is not supported on an OllamaServer", entry.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func TestServerResources(t *testing.T) {
+	server := &ollamav1alpha1.OllamaServer{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ollamav1alpha1.OllamaServerGroupVersionKind.GroupVersion().String(), Kind: ollamav1alpha1.OllamaServerKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default", UID: "uid"},
+		Spec: ollamav1alpha1.OllamaServerSpec{
+			Replicas: ptr.To(int32(2)),
+			Server:   &ollamav1alpha1.Server{NumParallel: ptr.To(int32(4))},
+		},
+	}
+	resources, err := ServerResources(server, Operator{})
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+
+	sts := decodeStatefulSet(t, resources[0])
+	require.Equal(t, "shared", sts.GetName())
+	require.Equal(t, ollamav1alpha1.OllamaServerKind, sts.GetOwnerReferences()[0].Kind)
+	require.Equal(t, int32(2), *sts.Spec.Replicas)
+	require.Equal(t, "shared", sts.Spec.Template.GetLabels()[serverLabelKey])
+	require.NotContains(t, sts.Spec.Template.GetLabels(), modelLabelKey, "pods of a server must not be mapped to a Model with the same name")
+
+	env := map[string]string{}
+	for _, e := range sts.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	require.Equal(t, "4", env["OLLAMA_NUM_PARALLEL"])
+	require.NotContains(t, env, "OLLAMA_MAX_LOADED_MODELS")
+
+	model := &ollamav1alpha1.Model{Spec: ollamav1alpha1.ModelSpec{ServerRef: &corev1.LocalObjectReference{Name: "shared"}}}
+	require.Equal(t, sts.Spec.Selector.MatchLabels, podLabels(model), "Models list the pods of the server")
+	require.Equal(t, "shared", statefulSetName(model))
+}
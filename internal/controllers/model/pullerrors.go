@@ -0,0 +1,61 @@
+package model
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	ollamaapi "github.com/ollama/ollama/api"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+const (
+	pullBackoffBase = 5 * time.Second
+	pullBackoffMax  = 5 * time.Minute
+)
+
+// classifyPullFailure returns the reason of pull failures that need a human intervention,
+// or an empty string for transient failures which are retried with exponential backoff.
+func classifyPullFailure(err error) ollamav1alpha1.PullFailureReason {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		// Ollama itself is not reachable, registry errors are reported by Ollama in the response
+		return ""
+	}
+	var statusErr ollamaapi.StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return ollamav1alpha1.PullFailureModelNotFound
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case containsAny(msg, "file does not exist", "manifest unknown", "model not found"):
+		return ollamav1alpha1.PullFailureModelNotFound
+	case containsAny(msg, "no space left on device", "disk full", "insufficient space"):
+		return ollamav1alpha1.PullFailureDiskFull
+	case containsAny(msg, "no such host", "connection refused", "i/o timeout", "network is unreachable", "tls handshake timeout"):
+		return ollamav1alpha1.PullFailureRegistryUnreachable
+	}
+	return ""
+}
+
+func containsAny(s string, substrs ...string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// pullBackoff returns the delay before the next pull attempt, doubling with every failed attempt.
+func pullBackoff(attempts int32) time.Duration {
+	backoff := pullBackoffBase
+	for i := int32(1); i < attempts && backoff < pullBackoffMax; i++ {
+		backoff *= 2
+	}
+	return min(backoff, pullBackoffMax)
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	ollamaapi "github.com/ollama/ollama/api"
+	"github.com/stretchr/testify/assert"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func Test_classifyPullFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ollamav1alpha1.PullFailureReason
+	}{
+		{
+			name: "unknown tag",
+			err:  errors.New("pull model manifest: file does not exist"),
+			want: ollamav1alpha1.PullFailureModelNotFound,
+		},
+		{
+			name: "not found status",
+			err:  ollamaapi.StatusError{StatusCode: http.StatusNotFound, ErrorMessage: "model not found"},
+			want: ollamav1alpha1.PullFailureModelNotFound,
+		},
+		{
+			name: "disk full",
+			err:  errors.New("write /root/.ollama/models/blobs/sha256-123-partial: no space left on device"),
+			want: ollamav1alpha1.PullFailureDiskFull,
+		},
+		{
+			name: "registry unreachable",
+			err:  errors.New(`pull model manifest: Get "https://registry.ollama.ai/v2/library/phi3/manifests/latest": dial tcp: lookup registry.ollama.ai: no such host`),
+			want: ollamav1alpha1.PullFailureRegistryUnreachable,
+		},
+		{
+			name: "ollama itself unreachable is transient",
+			err:  &url.Error{Op: "Post", URL: "http://10.0.0.1:11434/api/pull", Err: errors.New("dial tcp 10.0.0.1:11434: connect: connection refused")},
+			want: "",
+		},
+		{
+			name: "unexpected EOF is transient",
+			err:  errors.New("unexpected EOF"),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifyPullFailure(tt.err))
+		})
+	}
+}
+
+func Test_pullBackoff(t *testing.T) {
+	assert.Equal(t, 5*time.Second, pullBackoff(1))
+	assert.Equal(t, 10*time.Second, pullBackoff(2))
+	assert.Equal(t, 40*time.Second, pullBackoff(4))
+	assert.Equal(t, pullBackoffMax, pullBackoff(10))
+	assert.Equal(t, pullBackoffMax, pullBackoff(1000))
+}
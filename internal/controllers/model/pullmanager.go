@@ -0,0 +1,229 @@
+package model
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	ollamaapi "github.com/ollama/ollama/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/ollamaclient"
+)
+
+type pullKind int
+
+const (
+	// pullKindPull pulls or creates a missing model
+	pullKindPull pullKind = iota
+	// pullKindLoad loads an already pulled model into memory
+	pullKindLoad
+	// pullKindUpdate re-pulls an already pulled model to pick up changes of its tag
+	pullKindUpdate
+)
+
+// pullKey identifies a single model pull on a single Ollama pod of a Model.
+type pullKey struct {
+	model     types.NamespacedName
+	pod       string
+	modelName string
+	kind      pullKind
+}
+
+// pullFunc pulls the model, or creates it with Ollama's create API, reporting the progress to progressFunc.
+type pullFunc func(ctx context.Context, progressFunc ollamaapi.PullProgressFunc) error
+
+func pullModel(ollamaCli ollamaclient.Interface, modelName string, insecure bool) pullFunc {
+	return func(ctx context.Context, progressFunc ollamaapi.PullProgressFunc) error {
+		return ollamaCli.Pull(ctx, &ollamaapi.PullRequest{
+			Model:    modelName,
+			Insecure: insecure,
+			Stream:   ptr.To(true),
+		}, progressFunc)
+	}
+}
+
+func createModel(ollamaCli ollamaclient.Interface, create *modelCreate, open blobOpener) pullFunc {
+	return func(ctx context.Context, progressFunc ollamaapi.PullProgressFunc) error {
+		if err := uploadBlobs(ctx, ollamaCli, open, create.blobs, progressFunc); err != nil {
+			return err
+		}
+		return ollamaCli.Create(ctx, create.req, ollamaapi.CreateProgressFunc(progressFunc))
+	}
+}
+
+// loadModel loads the model into memory with a generate request without a prompt.
+func loadModel(ollamaCli ollamaclient.Interface, modelName string) pullFunc {
+	return func(ctx context.Context, progressFunc ollamaapi.PullProgressFunc) error {
+		err := ollamaCli.Generate(ctx, &ollamaapi.GenerateRequest{
+			Model:  modelName,
+			Stream: ptr.To(false),
+		}, func(ollamaapi.GenerateResponse) error { return nil })
+		if err != nil {
+			return err
+		}
+		return progressFunc(ollamaapi.ProgressResponse{Status: "success"})
+	}
+}
+
+// pull is a model pull running in the background.
+type pull struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	tracker *pullProgressTracker
+	status  string
+	err     error
+}
+
+// progress returns a snapshot of the pull progress.
+func (p *pull) progress() ollamav1alpha1.PullProgress {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return *p.tracker.progress.DeepCopy()
+}
+
+// result returns true and the outcome once the pull finished. Pull was successful if the error is nil and the status is "success".
+func (p *pull) result() (finished bool, status string, err error) {
+	select {
+	case <-p.done:
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return true, p.status, p.err
+	default:
+		return false, "", nil
+	}
+}
+
+// pullManager runs model pulls in the background, so they don't block reconcile workers and survive requeues.
+// Reconciler only starts, observes and cancels them. Once a pull finishes, the Model is enqueued via events channel.
+type pullManager struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	events chan event.TypedGenericEvent[*ollamav1alpha1.Model]
+	nowFn  func() time.Time
+
+	mu    sync.Mutex
+	pulls map[pullKey]*pull
+}
+
+func newPullManager(nowFn func() time.Time) *pullManager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &pullManager{
+		ctx:    ctx,
+		cancel: cancel,
+		events: make(chan event.TypedGenericEvent[*ollamav1alpha1.Model], 100),
+		nowFn:  nowFn,
+		pulls:  map[pullKey]*pull{},
+	}
+}
+
+// Start implements manager.Runnable, it cancels all pulls once the manager stops.
+func (m *pullManager) Start(ctx context.Context) error {
+	<-ctx.Done()
+	m.cancel()
+	return nil
+}
+
+// get returns the pull with given key, or nil if there's none.
+func (m *pullManager) get(key pullKey) *pull {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pulls[key]
+}
+
+// start runs pullFn in the background, unless the model is already being pulled.
+func (m *pullManager) start(ctx context.Context, key pullKey, pullFn pullFunc) *pull {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if p, ok := m.pulls[key]; ok {
+		return p
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithValues("model", key.modelName, "pod", key.pod)
+	pullCtx, cancel := context.WithCancel(ctrl.LoggerInto(m.ctx, log))
+	p := &pull{
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		tracker: newPullProgressTracker(key.modelName, key.pod, m.nowFn()),
+	}
+	m.pulls[key] = p
+
+	go func() {
+		defer close(p.done)
+		err := pullFn(pullCtx, func(resp ollamaapi.ProgressResponse) error {
+			log.V(4).Info("pulling model...", "progressResponse", resp)
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.status = resp.Status
+			p.tracker.observe(resp, m.nowFn())
+			return pullCtx.Err() // return early on cancel
+		})
+		p.mu.Lock()
+		p.err = err
+		status := p.status
+		p.mu.Unlock()
+		log.V(1).Info("finished pulling model", "status", status, "error", err)
+
+		if pullCtx.Err() != nil {
+			// cancelled, nobody waits for the result
+			return
+		}
+		select {
+		case m.events <- event.TypedGenericEvent[*ollamav1alpha1.Model]{Object: &ollamav1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: key.model.Name, Namespace: key.model.Namespace},
+		}}:
+		default:
+			// the Model is requeued periodically while the pull is running anyway
+		}
+	}()
+	return p
+}
+
+// find returns keys of the pulls of the Model for which match returns true.
+func (m *pullManager) find(model types.NamespacedName, match func(key pullKey) bool) []pullKey {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []pullKey
+	for key := range m.pulls {
+		if key.model == model && match(key) {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b pullKey) int { return strings.Compare(a.pod, b.pod) })
+	return keys
+}
+
+// forget cancels the pull if it's still running and drops it.
+func (m *pullManager) forget(key pullKey) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if p, ok := m.pulls[key]; ok {
+		p.cancel()
+		delete(m.pulls, key)
+	}
+}
+
+// cancelStale cancels and drops pulls of the Model for which keep returns false.
+func (m *pullManager) cancelStale(model types.NamespacedName, keep func(key pullKey) bool) []pullKey {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var cancelled []pullKey
+	for key, p := range m.pulls {
+		if key.model != model || keep(key) {
+			continue
+		}
+		p.cancel()
+		delete(m.pulls, key)
+		cancelled = append(cancelled, key)
+	}
+	return cancelled
+}
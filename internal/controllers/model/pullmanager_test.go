@@ -0,0 +1,78 @@
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ollama/ollama/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	"aerf.io/ollama-operator/internal/ollamaclient"
+)
+
+func Test_pullManager(t *testing.T) {
+	modelKey := types.NamespacedName{Namespace: "default", Name: "test"}
+
+	t.Run("finished pull enqueues the Model", func(t *testing.T) {
+		m := newPullManager(time.Now)
+		key := pullKey{model: modelKey, pod: "test-0", modelName: "phi3"}
+		p := m.start(context.Background(), key, pullModel(&ollamaclient.TestOllamaClient{
+			OnPull: func(ctx context.Context, req *api.PullRequest, progressFunc api.PullProgressFunc) error {
+				assert.Equal(t, "phi3", req.Model)
+				return progressFunc(api.ProgressResponse{Status: "success"})
+			},
+		}, key.modelName, false))
+		require.Same(t, p, m.start(context.Background(), key, nil), "pull must not be started twice")
+
+		select {
+		case e := <-m.events:
+			require.Equal(t, modelKey.Name, e.Object.GetName())
+			require.Equal(t, modelKey.Namespace, e.Object.GetNamespace())
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the pull to finish")
+		}
+		finished, status, err := p.result()
+		require.True(t, finished)
+		require.Equal(t, "success", status)
+		require.NoError(t, err)
+		require.Equal(t, int32(100), p.progress().Percent)
+
+		m.forget(key)
+		require.Nil(t, m.get(key))
+	})
+
+	t.Run("stale pulls are cancelled", func(t *testing.T) {
+		m := newPullManager(time.Now)
+		blockingClient := &ollamaclient.TestOllamaClient{
+			OnPull: func(ctx context.Context, req *api.PullRequest, progressFunc api.PullProgressFunc) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}
+		kept := pullKey{model: modelKey, pod: "test-0", modelName: "phi3"}
+		stale := pullKey{model: modelKey, pod: "test-0", modelName: "llama3.1"}
+		otherModel := pullKey{model: types.NamespacedName{Namespace: "default", Name: "other"}, pod: "other-0", modelName: "llama3.1"}
+		m.start(context.Background(), kept, pullModel(blockingClient, kept.modelName, false))
+		stalePull := m.start(context.Background(), stale, pullModel(blockingClient, stale.modelName, false))
+		m.start(context.Background(), otherModel, pullModel(blockingClient, otherModel.modelName, false))
+
+		cancelled := m.cancelStale(modelKey, func(key pullKey) bool { return key.modelName == "phi3" })
+		require.Equal(t, []pullKey{stale}, cancelled)
+
+		select {
+		case <-stalePull.done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the pull to be cancelled")
+		}
+		_, _, err := stalePull.result()
+		require.ErrorIs(t, err, context.Canceled)
+		require.NotNil(t, m.get(kept))
+		require.NotNil(t, m.get(otherModel))
+		require.Nil(t, m.get(stale))
+
+		m.cancel()
+	})
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"math"
+	"time"
+
+	ollamaapi "github.com/ollama/ollama/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+// pullProgressTracker aggregates the per-layer Ollama pull progress stream into PullProgress.
+type pullProgressTracker struct {
+	layers   map[string]ollamaapi.ProgressResponse
+	progress ollamav1alpha1.PullProgress
+}
+
+func newPullProgressTracker(modelName, pod string, start time.Time) *pullProgressTracker {
+	return &pullProgressTracker{
+		layers: map[string]ollamaapi.ProgressResponse{},
+		progress: ollamav1alpha1.PullProgress{
+			Model:     modelName,
+			Pod:       pod,
+			StartTime: ptr.To(metav1.NewTime(start)),
+		},
+	}
+}
+
+func (t *pullProgressTracker) observe(resp ollamaapi.ProgressResponse, now time.Time) {
+	t.progress.LastStatus = resp.Status
+	if resp.Digest != "" {
+		t.progress.Digest = resp.Digest
+		t.layers[resp.Digest] = resp
+	}
+
+	var completed, total int64
+	for _, layer := range t.layers {
+		completed += layer.Completed
+		total += layer.Total
+	}
+	t.progress.CompletedBytes = completed
+	t.progress.TotalBytes = total
+	if total > 0 {
+		t.progress.Percent = int32(math.Floor(float64(completed) * 100 / float64(total)))
+	}
+
+	t.progress.EstimatedTimeRemaining = nil
+	switch {
+	case resp.Status == "success":
+		t.progress.Percent = 100
+	case completed > 0 && completed < total:
+		elapsed := now.Sub(t.progress.StartTime.Time)
+		remaining := time.Duration(float64(elapsed) * float64(total-completed) / float64(completed))
+		t.progress.EstimatedTimeRemaining = &metav1.Duration{Duration: remaining.Round(time.Second)}
+	}
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	ollamaapi "github.com/ollama/ollama/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func Test_pullProgressTracker(t *testing.T) {
+	start := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		responses []ollamaapi.ProgressResponse
+		elapsed   time.Duration
+		want      ollamav1alpha1.PullProgress
+	}{
+		{
+			name:      "manifest only",
+			responses: []ollamaapi.ProgressResponse{{Status: "pulling manifest"}},
+			want: ollamav1alpha1.PullProgress{
+				Model:      "phi3",
+				Pod:        "phi3-0",
+				StartTime:  ptr.To(metav1.NewTime(start)),
+				LastStatus: "pulling manifest",
+			},
+		},
+		{
+			name: "progress is aggregated across layers",
+			responses: []ollamaapi.ProgressResponse{
+				{Status: "pulling a", Digest: "sha256:a", Total: 100, Completed: 100},
+				{Status: "pulling b", Digest: "sha256:b", Total: 300, Completed: 50},
+				{Status: "pulling b", Digest: "sha256:b", Total: 300, Completed: 100},
+			},
+			elapsed: 10 * time.Second,
+			want: ollamav1alpha1.PullProgress{
+				Model:                  "phi3",
+				Pod:                    "phi3-0",
+				StartTime:              ptr.To(metav1.NewTime(start)),
+				CompletedBytes:         200,
+				TotalBytes:             400,
+				Percent:                50,
+				Digest:                 "sha256:b",
+				EstimatedTimeRemaining: &metav1.Duration{Duration: 10 * time.Second},
+				LastStatus:             "pulling b",
+			},
+		},
+		{
+			name: "success",
+			responses: []ollamaapi.ProgressResponse{
+				{Status: "pulling a", Digest: "sha256:a", Total: 100, Completed: 100},
+				{Status: "verifying sha256 digest"},
+				{Status: "success"},
+			},
+			elapsed: 10 * time.Second,
+			want: ollamav1alpha1.PullProgress{
+				Model:          "phi3",
+				Pod:            "phi3-0",
+				StartTime:      ptr.To(metav1.NewTime(start)),
+				CompletedBytes: 100,
+				TotalBytes:     100,
+				Percent:        100,
+				Digest:         "sha256:a",
+				LastStatus:     "success",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newPullProgressTracker("phi3", "phi3-0", start)
+			for _, resp := range tt.responses {
+				tracker.observe(resp, start.Add(tt.elapsed))
+			}
+			if diff := cmp.Diff(tt.want, tracker.progress); diff != "" {
+				t.Errorf("progress mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"net/url"
+
+	ollamamodel "github.com/ollama/ollama/types/model"
+	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+const (
+	// key pair used by Ollama to authenticate to the registry, see github.com/ollama/ollama/auth
+	registryPrivateKey = "id_ed25519"
+	registryPublicKey  = "id_ed25519.pub"
+)
+
+func insecureRegistry(model *ollamav1alpha1.Model) bool {
+	return model.Spec.Registry != nil && model.Spec.Registry.Insecure
+}
+
+// resolvedRegistry returns the registry the model is pulled from, the same way Ollama resolves it.
+func resolvedRegistry(modelName string, insecure bool) string {
+	name := ollamamodel.ParseName(modelName)
+	if !name.IsValid() {
+		return ""
+	}
+	scheme := name.ProtocolScheme
+	if insecure {
+		// Ollama downgrades the requests to plain HTTP for insecure pulls
+		scheme = "http"
+	}
+	return (&url.URL{Scheme: scheme, Host: name.Host}).String()
+}
+
+func registryCredentialsVolumeName(model *ollamav1alpha1.Model) string {
+	return model.GetName() + "-registry-credentials"
+}
+
+// registryCredentialsVolume returns the volume with registry credentials Secret and its mounts, or nils if there are no credentials configured.
+func registryCredentialsVolume(model *ollamav1alpha1.Model) (*applycorev1.VolumeApplyConfiguration, []*applycorev1.VolumeMountApplyConfiguration) {
+	if model.Spec.Registry == nil || model.Spec.Registry.CredentialsSecretRef == nil {
+		return nil, nil
+	}
+
+	volume := applycorev1.Volume().
+		WithName(registryCredentialsVolumeName(model)).
+		WithSecret(applycorev1.SecretVolumeSource().
+			WithSecretName(model.Spec.Registry.CredentialsSecretRef.Name).
+			WithDefaultMode(0o400),
+		)
+	var mounts []*applycorev1.VolumeMountApplyConfiguration
+	for _, key := range []string{registryPrivateKey, registryPublicKey} {
+		mounts = append(mounts, applycorev1.VolumeMount().
+			WithName(registryCredentialsVolumeName(model)).
+			WithMountPath("/root/.ollama/"+key).
+			WithSubPath(key).
+			WithReadOnly(true),
+		)
+	}
+	return volume, mounts
+}
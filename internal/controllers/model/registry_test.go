@@ -0,0 +1,25 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_resolvedRegistry(t *testing.T) {
+	tests := []struct {
+		modelName string
+		insecure  bool
+		want      string
+	}{
+		{modelName: "phi3", want: "https://registry.ollama.ai"},
+		{modelName: "phi3:3.8b", want: "https://registry.ollama.ai"},
+		{modelName: "mirror.internal:5000/library/phi3:latest", want: "https://mirror.internal:5000"},
+		{modelName: "mirror.internal:5000/library/phi3:latest", insecure: true, want: "http://mirror.internal:5000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.modelName, func(t *testing.T) {
+			assert.Equal(t, tt.want, resolvedRegistry(tt.modelName, tt.insecure))
+		})
+	}
+}
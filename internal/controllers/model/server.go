@@ -0,0 +1,168 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+const ollamaContainerName = "ollama"
+
+// serverEnvNames are env vars rendered from spec.server, in the order they're set on the container.
+var serverEnvNames = []string{
+	"OLLAMA_KEEP_ALIVE",
+	"OLLAMA_DEBUG",
+	"OLLAMA_NUM_PARALLEL",
+	"OLLAMA_MAX_QUEUE",
+	"OLLAMA_FLASH_ATTENTION",
+	"OLLAMA_CONTEXT_LENGTH",
+	"OLLAMA_KV_CACHE_TYPE",
+	"OLLAMA_ORIGINS",
+}
+
+// serverEnv renders spec.server into env vars of the Ollama container. The second map contains only env vars
+// of fields that are set explicitly, patches overriding them are reported as conflicts.
+func serverEnv(model *ollamav1alpha1.Model) ([]corev1.EnvVar, map[string]string, error) {
+	env := []corev1.EnvVar{{Name: "OLLAMA_KEEP_ALIVE", Value: "-1"}} // infinity
+	if model.Spec.Idle != nil {
+		// Ollama's default, loaded models are how the use of the Model is observed
+		env[0].Value = "5m"
+	}
+	if desired := model.DesiredModels(); len(desired) > 0 {
+		// OllamaServers don't know their models upfront, Ollama's default is used for them
+		env = append(env, corev1.EnvVar{Name: "OLLAMA_MAX_LOADED_MODELS", Value: strconv.Itoa(len(desired))})
+	}
+	env = append(env, corev1.EnvVar{Name: "OLLAMA_DEBUG", Value: "false"})
+	server := model.Spec.Server
+	if server == nil {
+		return env, nil, nil
+	}
+
+	typed := map[string]string{}
+	if server.KeepAlive != "" {
+		if err := validateKeepAlive(server.KeepAlive); err != nil {
+			return nil, nil, err
+		}
+		typed["OLLAMA_KEEP_ALIVE"] = server.KeepAlive
+	}
+	if server.Debug != nil {
+		typed["OLLAMA_DEBUG"] = strconv.FormatBool(*server.Debug)
+	}
+	if server.NumParallel != nil {
+		typed["OLLAMA_NUM_PARALLEL"] = strconv.Itoa(int(*server.NumParallel))
+	}
+	if server.MaxQueue != nil {
+		typed["OLLAMA_MAX_QUEUE"] = strconv.Itoa(int(*server.MaxQueue))
+	}
+	if server.FlashAttention != nil {
+		typed["OLLAMA_FLASH_ATTENTION"] = strconv.FormatBool(*server.FlashAttention)
+	}
+	if server.ContextLength != nil {
+		typed["OLLAMA_CONTEXT_LENGTH"] = strconv.Itoa(int(*server.ContextLength))
+	}
+	if server.KVCacheType != "" {
+		if server.KVCacheType != "f16" && (server.FlashAttention == nil || !*server.FlashAttention) {
+			return nil, nil, fmt.Errorf("spec.server.kvCacheType %q requires flashAttention", server.KVCacheType)
+		}
+		typed["OLLAMA_KV_CACHE_TYPE"] = server.KVCacheType
+	}
+	if len(server.Origins) > 0 {
+		typed["OLLAMA_ORIGINS"] = strings.Join(server.Origins, ",")
+	}
+
+	for i := range env {
+		if value, ok := typed[env[i].Name]; ok {
+			env[i].Value = value
+		}
+	}
+	// keep the order stable, so the StatefulSet isn't rolled out needlessly
+	for _, name := range serverEnvNames {
+		value, ok := typed[name]
+		if ok && !slices.ContainsFunc(env, func(e corev1.EnvVar) bool { return e.Name == name }) {
+			env = append(env, corev1.EnvVar{Name: name, Value: value})
+		}
+	}
+	return env, typed, nil
+}
+
+// validateKeepAlive accepts the same values as Ollama: a duration or a number of seconds.
+func validateKeepAlive(keepAlive string) error {
+	if _, err := strconv.Atoi(keepAlive); err == nil {
+		return nil
+	}
+	if _, err := time.ParseDuration(keepAlive); err != nil {
+		return fmt.Errorf("invalid spec.server.keepAlive %q: %s", keepAlive, err)
+	}
+	return nil
+}
+
+// serverConfigConflicts returns names of env vars set from spec.server that are overridden or removed by statefulSetPatches.
+func serverConfigConflicts(typed map[string]string, sts *unstructured.Unstructured) ([]string, error) {
+	if len(typed) == 0 {
+		return nil, nil
+	}
+	containers, _, err := unstructured.NestedSlice(sts.Object, "spec", "template", "spec", "containers")
+	if err != nil {
+		return nil, err
+	}
+	actual := map[string]string{}
+	for _, c := range containers {
+		container := &corev1.Container{}
+		obj, ok := c.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type %T of container", c)
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, container); err != nil {
+			return nil, err
+		}
+		if container.Name != ollamaContainerName {
+			continue
+		}
+		for _, env := range container.Env {
+			actual[env.Name] = env.Value
+		}
+	}
+
+	var conflicts []string
+	for _, name := range serverEnvNames {
+		want, ok := typed[name]
+		if !ok {
+			continue
+		}
+		if got, found := actual[name]; !found || got != want {
+			conflicts = append(conflicts, name)
+		}
+	}
+	return conflicts, nil
+}
+
+// setServerConfiguredCondition reports conflicts between spec.server and statefulSetPatches.
+func setServerConfiguredCondition(model *ollamav1alpha1.Model, conflicts []string) {
+	if model.Spec.Server == nil {
+		// nothing to report, drop the condition left behind by a removed spec.server
+		model.Status.RemoveConditions(ollamav1alpha1.TypeServerConfigured)
+		return
+	}
+	cond := xpv2.Condition{
+		Type:               ollamav1alpha1.TypeServerConfigured,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ollamav1alpha1.ReasonConfigured,
+	}
+	if len(conflicts) > 0 {
+		cond.Status = corev1.ConditionFalse
+		cond.Reason = ollamav1alpha1.ReasonPatchConflict
+		cond.Message = fmt.Sprintf("statefulSetPatches override %s set in spec.server", strings.Join(conflicts, ", "))
+	}
+	model.SetConditionsWithObservedGeneration(cond)
+}
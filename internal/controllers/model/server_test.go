@@ -0,0 +1,125 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func Test_serverEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		server  *ollamav1alpha1.Server
+		want    []corev1.EnvVar
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			want: []corev1.EnvVar{
+				{Name: "OLLAMA_KEEP_ALIVE", Value: "-1"},
+				{Name: "OLLAMA_MAX_LOADED_MODELS", Value: "1"},
+				{Name: "OLLAMA_DEBUG", Value: "false"},
+			},
+		},
+		{
+			name: "all fields",
+			server: &ollamav1alpha1.Server{
+				KeepAlive:      "10m",
+				NumParallel:    ptr.To(int32(4)),
+				MaxQueue:       ptr.To(int32(128)),
+				FlashAttention: ptr.To(true),
+				ContextLength:  ptr.To(int32(8192)),
+				KVCacheType:    "q8_0",
+				Debug:          ptr.To(true),
+				Origins:        []string{"https://chat.example.com", "app://*"},
+			},
+			want: []corev1.EnvVar{
+				{Name: "OLLAMA_KEEP_ALIVE", Value: "10m"},
+				{Name: "OLLAMA_MAX_LOADED_MODELS", Value: "1"},
+				{Name: "OLLAMA_DEBUG", Value: "true"},
+				{Name: "OLLAMA_NUM_PARALLEL", Value: "4"},
+				{Name: "OLLAMA_MAX_QUEUE", Value: "128"},
+				{Name: "OLLAMA_FLASH_ATTENTION", Value: "true"},
+				{Name: "OLLAMA_CONTEXT_LENGTH", Value: "8192"},
+				{Name: "OLLAMA_KV_CACHE_TYPE", Value: "q8_0"},
+				{Name: "OLLAMA_ORIGINS", Value: "https://chat.example.com,app://*"},
+			},
+		},
+		{
+			name:   "keep alive in seconds",
+			server: &ollamav1alpha1.Server{KeepAlive: "300"},
+			want: []corev1.EnvVar{
+				{Name: "OLLAMA_KEEP_ALIVE", Value: "300"},
+				{Name: "OLLAMA_MAX_LOADED_MODELS", Value: "1"},
+				{Name: "OLLAMA_DEBUG", Value: "false"},
+			},
+		},
+		{
+			name:    "invalid keep alive",
+			server:  &ollamav1alpha1.Server{KeepAlive: "forever"},
+			wantErr: true,
+		},
+		{
+			name:    "quantized kv cache without flash attention",
+			server:  &ollamav1alpha1.Server{KVCacheType: "q4_0"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, _, err := serverEnv(&ollamav1alpha1.Model{Spec: ollamav1alpha1.ModelSpec{Model: "phi3", Server: tt.server}})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, env)
+		})
+	}
+}
+
+func Test_serverConfigConflicts(t *testing.T) {
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: ollamav1alpha1.ModelSpec{
+			Model:  "phi3",
+			Server: &ollamav1alpha1.Server{KeepAlive: "5m", NumParallel: ptr.To(int32(2))},
+			StatefulSetPatches: &ollamav1alpha1.Patches{
+				JSONPatch: ollamav1alpha1.JSONPatch{JSONPatch: []ollamav1alpha1.JSONPatchOperation{
+					{Op: "replace", Path: "/spec/template/spec/containers/0/env/0/value", Value: &runtime.RawExtension{Raw: []byte(`"-1"`)}},
+					{Op: "replace", Path: "/spec/template/spec/containers/0/env/2/value", Value: &runtime.RawExtension{Raw: []byte(`"true"`)}},
+				}},
+			},
+		},
+	}
+	_, typed, err := serverEnv(model)
+	require.NoError(t, err)
+	conflicts, err := serverConfigConflicts(typed, statefulSetResource(t, model, Operator{}))
+	require.NoError(t, err)
+	// OLLAMA_DEBUG isn't set in spec.server, so patching it is not a conflict
+	require.Equal(t, []string{"OLLAMA_KEEP_ALIVE"}, conflicts)
+}
+
+func Test_setServerConfiguredCondition(t *testing.T) {
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 1},
+		Spec:       ollamav1alpha1.ModelSpec{Model: "phi3", Server: &ollamav1alpha1.Server{KeepAlive: "5m"}},
+	}
+	setServerConfiguredCondition(model, nil)
+	require.Equal(t, corev1.ConditionTrue, model.GetCondition(ollamav1alpha1.TypeServerConfigured).Status)
+
+	setServerConfiguredCondition(model, []string{"OLLAMA_KEEP_ALIVE"})
+	require.Equal(t, corev1.ConditionFalse, model.GetCondition(ollamav1alpha1.TypeServerConfigured).Status)
+	require.Equal(t, ollamav1alpha1.ReasonPatchConflict, model.GetCondition(ollamav1alpha1.TypeServerConfigured).Reason)
+
+	model.Spec.Server = nil
+	model.SetGeneration(2)
+	setServerConfiguredCondition(model, nil)
+	require.Empty(t, model.Status.Conditions, "removed together with spec.server")
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"fmt"
+
+	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+// ollamaModelsDir is Ollama's default models directory, on the storage volume mounted at /root/.ollama.
+const ollamaModelsDir = "/root/.ollama/models"
+
+// seedsFromImage returns true if the models directory is the read-only image volume from spec.source.imageVolume.
+func seedsFromImage(model *ollamav1alpha1.Model) bool {
+	return model.Spec.Source != nil && model.Spec.Source.ImageVolume != nil
+}
+
+func seedVolumeName(model *ollamav1alpha1.Model) string {
+	return model.GetName() + "-models-seed"
+}
+
+// seedImageVolume returns the image volume from spec.source.imageVolume and its mount over Ollama's models directory,
+// or nils if it's not set.
+func seedImageVolume(model *ollamav1alpha1.Model) (*applycorev1.VolumeApplyConfiguration, *applycorev1.VolumeMountApplyConfiguration) {
+	if !seedsFromImage(model) {
+		return nil, nil
+	}
+	image := applycorev1.ImageVolumeSource().WithReference(model.Spec.Source.ImageVolume.Reference)
+	if model.Spec.Source.ImageVolume.PullPolicy != "" {
+		image.WithPullPolicy(model.Spec.Source.ImageVolume.PullPolicy)
+	}
+	volume := applycorev1.Volume().
+		WithName(seedVolumeName(model)).
+		WithImage(image)
+	mount := applycorev1.VolumeMount().
+		WithName(seedVolumeName(model)).
+		WithMountPath(ollamaModelsDir).
+		WithReadOnly(true)
+	return volume, mount
+}
+
+// noPruneEnv stops Ollama from pruning unused blobs on start, the models directory is read-only with an image volume.
+func noPruneEnv() *applycorev1.EnvVarApplyConfiguration {
+	return applycorev1.EnvVar().WithName("OLLAMA_NOPRUNE").WithValue("true")
+}
+
+// seedDataSource returns the VolumeSnapshot from spec.source.snapshot the PVCs are restored from, or nil if it's not set.
+func seedDataSource(model *ollamav1alpha1.Model) *applycorev1.TypedLocalObjectReferenceApplyConfiguration {
+	if model.Spec.Source == nil || model.Spec.Source.Snapshot == nil {
+		return nil
+	}
+	return applycorev1.TypedLocalObjectReference().
+		WithAPIGroup("snapshot.storage.k8s.io").
+		WithKind("VolumeSnapshot").
+		WithName(model.Spec.Source.Snapshot.Name)
+}
+
+// seedSource describes spec.source for the status of seeded models, it's empty if the Model has no source.
+func seedSource(model *ollamav1alpha1.Model) string {
+	switch {
+	case model.Spec.Source == nil:
+		return ""
+	case model.Spec.Source.ImageVolume != nil:
+		return fmt.Sprintf("imageVolume %s", model.Spec.Source.ImageVolume.Reference)
+	case model.Spec.Source.Snapshot != nil:
+		return fmt.Sprintf("snapshot %s", model.Spec.Source.Snapshot.Name)
+	default:
+		return ""
+	}
+}
+
+// seededBeforePull returns true if the model, present on all replicas and never pulled by the operator, comes from
+// spec.source. Nothing can be pulled into an image volume. Otherwise it was present before any pull was started, except
+// with spec.pullMode InitContainer, as models pulled by the init container can't be told apart from the seeded ones.
+func seededBeforePull(model *ollamav1alpha1.Model, entryStatus *ollamav1alpha1.ModelEntryStatus) bool {
+	switch {
+	case seedsFromImage(model):
+		return true
+	case pullsInInitContainer(model):
+		return false
+	default:
+		return entryStatus.PullState == ollamav1alpha1.PullStatePending
+	}
+}
+
+// setNotInSeedImage marks the model missing in the image volume from spec.source as stalled, nothing can be pulled
+// into the read-only volume.
+func (r *Reconciler) setNotInSeedImage(model *ollamav1alpha1.Model, entry ollamav1alpha1.ModelEntry) {
+	entryStatus := model.ModelStatusFor(entry.Name)
+	if entryStatus.FailureReason != ollamav1alpha1.PullFailureNotInSeedImage {
+		r.eventRecorderFor(model).WarningEventf("PullingModel", "NotInSeedImage", "Model %q is missing in the %s image", entry.Name, model.Spec.Source.ImageVolume.Reference)
+		entryStatus.FailedGeneration = model.GetGeneration()
+	}
+	entryStatus.PullState = ollamav1alpha1.PullStateFailed
+	entryStatus.FailureReason = ollamav1alpha1.PullFailureNotInSeedImage
+	entryStatus.LastPullError = fmt.Sprintf("model is missing in the %s image, it can't be pulled into the read-only image volume", model.Spec.Source.ImageVolume.Reference)
+	entryStatus.NextPullAttemptTime = nil
+	entryStatus.Message = fmt.Sprintf("%s, update the spec to retry", entryStatus.FailureReason)
+}
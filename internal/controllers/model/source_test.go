@@ -0,0 +1,135 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/ollama/ollama/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/ollamaclient"
+)
+
+func TestResources_source(t *testing.T) {
+	newModel := func(source *ollamav1alpha1.ModelSource) *ollamav1alpha1.Model {
+		return &ollamav1alpha1.Model{
+			TypeMeta:   metav1.TypeMeta{APIVersion: ollamav1alpha1.ModelGroupVersionKind.GroupVersion().String(), Kind: ollamav1alpha1.ModelKind},
+			ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default", UID: "uid"},
+			Spec:       ollamav1alpha1.ModelSpec{Model: "phi3", Source: source},
+		}
+	}
+
+	t.Run("image volume", func(t *testing.T) {
+		sts := statefulSetOf(t, newModel(&ollamav1alpha1.ModelSource{ImageVolume: &corev1.ImageVolumeSource{Reference: "ghcr.io/example/phi3-weights:v1"}}), Operator{})
+		require.Contains(t, sts.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name:         "phi3-models-seed",
+			VolumeSource: corev1.VolumeSource{Image: &corev1.ImageVolumeSource{Reference: "ghcr.io/example/phi3-weights:v1"}},
+		})
+		ollama := sts.Spec.Template.Spec.Containers[0]
+		require.Equal(t, []corev1.VolumeMount{
+			{Name: "phi3-ollama-root", MountPath: "/root/.ollama"},
+			{Name: "phi3-models-seed", MountPath: "/root/.ollama/models", ReadOnly: true},
+		}, ollama.VolumeMounts, "the seed is mounted over the storage volume")
+		require.Contains(t, ollama.Env, corev1.EnvVar{Name: "OLLAMA_NOPRUNE", Value: "true"})
+		require.Nil(t, sts.Spec.VolumeClaimTemplates[0].Spec.DataSource)
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		sts := statefulSetOf(t, newModel(&ollamav1alpha1.ModelSource{Snapshot: &corev1.LocalObjectReference{Name: "phi3-weights"}}), Operator{})
+		require.Equal(t, &corev1.TypedLocalObjectReference{
+			APIGroup: ptr.To("snapshot.storage.k8s.io"),
+			Kind:     "VolumeSnapshot",
+			Name:     "phi3-weights",
+		}, sts.Spec.VolumeClaimTemplates[0].Spec.DataSource)
+	})
+}
+
+func Test_updateModelStatuses_seeded(t *testing.T) {
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default"},
+		Spec: ollamav1alpha1.ModelSpec{
+			Model:  "phi3",
+			Models: []ollamav1alpha1.ModelEntry{{Name: "llama3.1"}},
+			Source: &ollamav1alpha1.ModelSource{Snapshot: &corev1.LocalObjectReference{Name: "phi3-weights"}},
+		},
+		Status: ollamav1alpha1.ModelStatus{
+			Models: []ollamav1alpha1.ModelEntryStatus{
+				{Name: "phi3", PullState: ollamav1alpha1.PullStatePending},
+				{Name: "llama3.1", PullState: ollamav1alpha1.PullStatePulling},
+			},
+			Replicas: []ollamav1alpha1.ReplicaStatus{{Pod: "phi3-0", Ready: true, Models: []string{"phi3", "llama3.1"}}},
+		},
+	}
+
+	updateModelStatuses(model, model.DesiredModels())
+	require.Equal(t, "snapshot phi3-weights", model.ModelStatusFor("phi3").SeededFrom, "present before any pull")
+	require.Empty(t, model.ModelStatusFor("llama3.1").SeededFrom, "pulled by the operator")
+	require.Equal(t, []string{"llama3.1"}, model.Status.PulledModels, "seeded models are not deleted by the operator")
+
+	updateModelStatuses(model, model.DesiredModels())
+	require.Equal(t, "snapshot phi3-weights", model.ModelStatusFor("phi3").SeededFrom)
+	require.Empty(t, model.ModelStatusFor("llama3.1").SeededFrom)
+}
+
+func Test_updateModelStatuses_seededWithInitContainer(t *testing.T) {
+	newModel := func(source *ollamav1alpha1.ModelSource) *ollamav1alpha1.Model {
+		return &ollamav1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default"},
+			Spec:       ollamav1alpha1.ModelSpec{Model: "phi3", PullMode: ollamav1alpha1.PullModeInitContainer, Source: source},
+			Status: ollamav1alpha1.ModelStatus{
+				// the init container finished pulling before the operator saw the pod
+				Models:   []ollamav1alpha1.ModelEntryStatus{{Name: "phi3", PullState: ollamav1alpha1.PullStatePending}},
+				Replicas: []ollamav1alpha1.ReplicaStatus{{Pod: "phi3-0", Ready: true, Models: []string{"phi3"}}},
+			},
+		}
+	}
+
+	model := newModel(&ollamav1alpha1.ModelSource{Snapshot: &corev1.LocalObjectReference{Name: "phi3-weights"}})
+	updateModelStatuses(model, model.DesiredModels())
+	require.Empty(t, model.ModelStatusFor("phi3").SeededFrom)
+	require.Equal(t, []string{"phi3"}, model.Status.PulledModels)
+
+	model = newModel(&ollamav1alpha1.ModelSource{ImageVolume: &corev1.ImageVolumeSource{Reference: "ghcr.io/example/phi3-weights:v1"}})
+	updateModelStatuses(model, model.DesiredModels())
+	require.Equal(t, "imageVolume ghcr.io/example/phi3-weights:v1", model.ModelStatusFor("phi3").SeededFrom)
+	require.Empty(t, model.Status.PulledModels)
+}
+
+func TestReconciler_Reconcile_notInSeedImage(t *testing.T) {
+	c := newFakeModelCluster(t, &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default"},
+		Spec: ollamav1alpha1.ModelSpec{
+			Model:  "phi3",
+			Models: []ollamav1alpha1.ModelEntry{{Name: "llama3.1"}},
+			Source: &ollamav1alpha1.ModelSource{ImageVolume: &corev1.ImageVolumeSource{Reference: "ghcr.io/example/phi3-weights:v1"}},
+		},
+	}, &ollamaclient.TestOllamaClient{
+		OnList: func(context.Context) (*api.ListResponse, error) {
+			return &api.ListResponse{Models: []api.ListModelResponse{{Name: "phi3:latest", Model: "phi3:latest"}}}, nil
+		},
+		OnPull: func(context.Context, *api.PullRequest, api.PullProgressFunc) error {
+			t.Fatal("nothing can be pulled into the image volume")
+			return nil
+		},
+		OnShow:        func(context.Context, *api.ShowRequest) (*api.ShowResponse, error) { return &api.ShowResponse{}, nil },
+		OnListRunning: func(context.Context) (*api.ProcessResponse, error) { return &api.ProcessResponse{}, nil },
+	})
+
+	model := c.reconcile(t)
+	require.Equal(t, ollamav1alpha1.PullStatePulled, model.ModelStatusFor("phi3").PullState)
+	entryStatus := model.ModelStatusFor("llama3.1")
+	require.Equal(t, ollamav1alpha1.PullStateFailed, entryStatus.PullState)
+	require.Equal(t, ollamav1alpha1.PullFailureNotInSeedImage, entryStatus.FailureReason)
+	stalled := model.GetCondition(ollamav1alpha1.TypeStalled)
+	require.Equal(t, corev1.ConditionTrue, stalled.Status)
+	require.Equal(t, xpv2.ConditionReason(ollamav1alpha1.PullFailureNotInSeedImage), stalled.Reason)
+
+	c.updateSpec(t, func(spec *ollamav1alpha1.ModelSpec) { spec.Models = nil })
+	model = c.reconcile(t)
+	require.Equal(t, corev1.ConditionFalse, model.GetCondition(ollamav1alpha1.TypeStalled).Status)
+}
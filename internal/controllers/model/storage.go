@@ -0,0 +1,181 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimachineryresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/eventrecorder"
+)
+
+var defaultStorageSize = apimachineryresource.MustParse("20Gi")
+
+func storageVolumeName(model *ollamav1alpha1.Model) string {
+	return model.GetName() + "-ollama-root"
+}
+
+func usesEmptyDir(model *ollamav1alpha1.Model) bool {
+	return model.Spec.Storage != nil && model.Spec.Storage.EmptyDir != nil
+}
+
+// retentionPolicy returns spec.storage.retentionPolicy defaulted to Retain, or an empty policy if the Model has no PVCs.
+func retentionPolicy(model *ollamav1alpha1.Model) ollamav1alpha1.PVCRetentionPolicy {
+	switch {
+	case model.Spec.ServerRef != nil || usesEmptyDir(model):
+		return ""
+	case model.Spec.Storage != nil && model.Spec.Storage.RetentionPolicy != "":
+		return model.Spec.Storage.RetentionPolicy
+	default:
+		return ollamav1alpha1.PVCRetentionPolicyRetain
+	}
+}
+
+func desiredStorageSize(model *ollamav1alpha1.Model) apimachineryresource.Quantity {
+	if model.Spec.Storage != nil && model.Spec.Storage.Size != nil {
+		return *model.Spec.Storage.Size
+	}
+	return defaultStorageSize
+}
+
+// KeepVolumeClaimTemplateSize sets the storage request of the volume claim template in the applied StatefulSet to the one
+// of the existing StatefulSet, as volumeClaimTemplates are immutable. PVCs are expanded directly instead, see expandVolumes.
+func KeepVolumeClaimTemplateSize(unstructuredSts *unstructured.Unstructured, existing *appsv1.StatefulSet) error {
+	templates, found, err := unstructured.NestedSlice(unstructuredSts.Object, "spec", "volumeClaimTemplates")
+	if err != nil || !found {
+		return err
+	}
+	for i := range templates {
+		tmpl, ok := templates[i].(map[string]any)
+		if !ok {
+			return fmt.Errorf("unexpected type %T of volume claim template", templates[i])
+		}
+		name, _, err := unstructured.NestedString(tmpl, "metadata", "name")
+		if err != nil {
+			return err
+		}
+		for _, existingTmpl := range existing.Spec.VolumeClaimTemplates {
+			existingSize, ok := existingTmpl.Spec.Resources.Requests[corev1.ResourceStorage]
+			if existingTmpl.GetName() != name || !ok {
+				continue
+			}
+			if err := unstructured.SetNestedField(tmpl, existingSize.String(), "spec", "resources", "requests", string(corev1.ResourceStorage)); err != nil {
+				return err
+			}
+		}
+		templates[i] = tmpl
+	}
+	return unstructured.SetNestedSlice(unstructuredSts.Object, templates, "spec", "volumeClaimTemplates")
+}
+
+// expandVolumes grows PVCs of the Model and reports the progress in the StorageExpanded condition.
+func (r *Reconciler) expandVolumes(ctx context.Context, model *ollamav1alpha1.Model) error {
+	cond, err := expandVolumes(ctx, r.client, r.eventRecorderFor(model), model)
+	if err != nil || cond == nil {
+		return err
+	}
+	model.SetConditionsWithObservedGeneration(*cond)
+	return nil
+}
+
+// ExpandServerVolumes grows PVCs of the OllamaServer to the size from spec, see expandVolumes.
+func ExpandServerVolumes(ctx context.Context, cli client.Client, recorder *eventrecorder.EventRecorder, server *ollamav1alpha1.OllamaServer) (*xpv2.Condition, error) {
+	return expandVolumes(ctx, cli, recorder, serverModel(server))
+}
+
+// expandVolumes grows PVCs of all replicas to the size from spec and returns the StorageExpanded condition reporting
+// the progress, or nil if the Model doesn't have PVCs.
+func expandVolumes(ctx context.Context, cli client.Client, recorder *eventrecorder.EventRecorder, model *ollamav1alpha1.Model) (*xpv2.Condition, error) {
+	if usesEmptyDir(model) {
+		return nil, nil
+	}
+	desired := desiredStorageSize(model)
+
+	var expanding, shrinking []string
+	for i := range ptr.Deref(model.Spec.Replicas, 1) {
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := cli.Get(ctx, client.ObjectKey{
+			Namespace: model.GetNamespace(),
+			// naming scheme of PVCs created by the StatefulSet controller
+			Name: fmt.Sprintf("%s-%s-%d", storageVolumeName(model), model.GetName(), i),
+		}, pvc); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrap(err, "failed to fetch PVC")
+		}
+
+		requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		switch requested.Cmp(desired) {
+		case -1:
+			patch := client.MergeFrom(pvc.DeepCopy())
+			if pvc.Spec.Resources.Requests == nil {
+				pvc.Spec.Resources.Requests = corev1.ResourceList{}
+			}
+			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = desired
+			if err := cli.Patch(ctx, pvc, patch); err != nil {
+				return nil, errors.Wrapf(err, "failed to expand PVC %s", pvc.GetName())
+			}
+			recorder.NormalEventf("ExpandingVolume", "ExpandingVolume", "Expanding PVC %s from %s to %s", pvc.GetName(), requested.String(), desired.String())
+		case 1:
+			shrinking = append(shrinking, pvc.GetName())
+			continue
+		}
+
+		capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+		if !ok || capacity.Cmp(desired) < 0 {
+			expanding = append(expanding, pvc.GetName())
+		}
+	}
+
+	cond := xpv2.Condition{
+		Type:               ollamav1alpha1.TypeStorageExpanded,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ollamav1alpha1.ReasonExpanded,
+	}
+	switch {
+	case len(shrinking) > 0:
+		cond.Status = corev1.ConditionFalse
+		cond.Reason = ollamav1alpha1.ReasonShrinkNotSupported
+		cond.Message = fmt.Sprintf("PVCs %s are bigger than %s, shrinking is not supported", strings.Join(shrinking, ", "), desired.String())
+	case len(expanding) > 0:
+		cond.Status = corev1.ConditionFalse
+		cond.Reason = ollamav1alpha1.ReasonExpanding
+		cond.Message = fmt.Sprintf("Waiting for PVCs %s to be expanded to %s", strings.Join(expanding, ", "), desired.String())
+	}
+	return &cond, nil
+}
+
+// deleteVolumes deletes the PVCs created by the StatefulSet of the Model. The StatefulSet controller deletes them too
+// with the persistentVolumeClaimRetentionPolicy, this covers clusters that don't support it. PVCs still used by
+// terminating pods are removed once the pods are gone.
+func (r *Reconciler) deleteVolumes(ctx context.Context, model *ollamav1alpha1.Model) error {
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	// PVCs get the labels of the StatefulSet selector
+	if err := r.client.List(ctx, pvcs, client.InNamespace(model.GetNamespace()), client.MatchingLabels{modelLabelKey: model.GetName()}); err != nil {
+		return errors.Wrap(err, "failed to list PVCs")
+	}
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		if pvc.GetDeletionTimestamp() != nil || !strings.HasPrefix(pvc.GetName(), storageVolumeName(model)+"-") {
+			continue
+		}
+		if err := r.client.Delete(ctx, pvc); client.IgnoreNotFound(err) != nil {
+			return errors.Wrapf(err, "failed to delete PVC %s", pvc.GetName())
+		}
+		r.eventRecorderFor(model).NormalEventf("DeletingVolume", "DeletedVolume", "Deleted PVC %s", pvc.GetName())
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimachineryresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func Test_keepVolumeClaimTemplateSize(t *testing.T) {
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: ollamav1alpha1.ModelSpec{
+			Model: "phi3",
+			Storage: &ollamav1alpha1.Storage{
+				Size: ptr.To(apimachineryresource.MustParse("50Gi")),
+			},
+		},
+	}
+	existingWithSize := func(size string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			Spec: appsv1.StatefulSetSpec{
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: storageVolumeName(model)},
+						Spec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.VolumeResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceStorage: apimachineryresource.MustParse(size)},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		existing *appsv1.StatefulSet
+		wantSize string
+	}{
+		{
+			name:     "size from existing statefulset is kept",
+			existing: existingWithSize("20Gi"),
+			wantSize: "20Gi",
+		},
+		{
+			name:     "unrelated volume claim templates are ignored",
+			existing: &appsv1.StatefulSet{},
+			wantSize: "50Gi",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sts := statefulSetResource(t, model, Operator{})
+			require.NoError(t, KeepVolumeClaimTemplateSize(sts, tt.existing))
+
+			templates, found, err := unstructured.NestedSlice(sts.Object, "spec", "volumeClaimTemplates")
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Len(t, templates, 1)
+			size, _, err := unstructured.NestedString(templates[0].(map[string]any), "spec", "resources", "requests", "storage")
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSize, size)
+		})
+	}
+}
+
+func TestResources_retentionPolicy(t *testing.T) {
+	model := &ollamav1alpha1.Model{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ollamav1alpha1.ModelGroupVersionKind.GroupVersion().String(), Kind: ollamav1alpha1.ModelKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default", UID: "uid"},
+		Spec:       ollamav1alpha1.ModelSpec{Model: "phi3"},
+	}
+	policyOf := func(model *ollamav1alpha1.Model) *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy {
+		return statefulSetOf(t, model, Operator{}).Spec.PersistentVolumeClaimRetentionPolicy
+	}
+
+	require.Nil(t, policyOf(model))
+	require.Equal(t, ollamav1alpha1.PVCRetentionPolicyRetain, retentionPolicy(model))
+
+	model.Spec.Storage = &ollamav1alpha1.Storage{RetentionPolicy: ollamav1alpha1.PVCRetentionPolicyDelete}
+	require.Equal(t, &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+		WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+		WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+	}, policyOf(model))
+	require.Equal(t, ollamav1alpha1.PVCRetentionPolicyDelete, retentionPolicy(model))
+
+	model.Spec.Storage = &ollamav1alpha1.Storage{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	require.Empty(t, retentionPolicy(model))
+}
+
+func TestReconciler_finalize_volumes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, ollamav1alpha1.AddToScheme(scheme))
+
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "phi3",
+			Namespace:  "default",
+			Finalizers: []string{ollamav1alpha1.ModelVolumesFinalizer},
+		},
+		Spec: ollamav1alpha1.ModelSpec{
+			Model:   "phi3",
+			Storage: &ollamav1alpha1.Storage{RetentionPolicy: ollamav1alpha1.PVCRetentionPolicyDelete},
+		},
+	}
+	pvc := func(name, model string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{modelLabelKey: model},
+		}}
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		model,
+		pvc("phi3-ollama-root-phi3-0", "phi3"),
+		pvc("phi3-ollama-root-phi3-1", "phi3"),
+		pvc("phi3-other", "phi3"),
+		pvc("llama-ollama-root-llama-0", "llama"),
+	).Build()
+	r := &Reconciler{client: cli, recorder: record.NewEventRecorderAdapter(record.NewFakeRecorder(100))}
+	ctx := context.Background()
+
+	require.NoError(t, r.finalize(ctx, model))
+	require.Empty(t, model.GetFinalizers())
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	require.NoError(t, cli.List(ctx, pvcs))
+	var names []string
+	for _, pvc := range pvcs.Items {
+		names = append(names, pvc.GetName())
+	}
+	require.ElementsMatch(t, []string{"phi3-other", "llama-ollama-root-llama-0"}, names)
+}
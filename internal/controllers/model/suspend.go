@@ -0,0 +1,188 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+// isPaused reports whether reconciliation of the Model is paused with ModelPausedAnnotation.
+func isPaused(model *ollamav1alpha1.Model) bool {
+	return model.GetAnnotations()[ollamav1alpha1.ModelPausedAnnotation] == "true"
+}
+
+// observePaused reports a paused Model with the changes applying its generated objects would make, nothing is applied.
+func (r *Reconciler) observePaused(ctx context.Context, model *ollamav1alpha1.Model) error {
+	if model.Spec.ServerRef == nil {
+		changes, err := r.pendingChanges(ctx, model)
+		if err != nil {
+			return err
+		}
+		model.Status.PendingChanges = changes
+	} else {
+		model.Status.PendingChanges = nil
+	}
+	if cond := model.GetCondition(ollamav1alpha1.TypePaused); cond.Status != corev1.ConditionTrue {
+		r.eventRecorderFor(model).NormalEventf("Pausing", "Paused", "Reconciliation paused with the %s annotation", ollamav1alpha1.ModelPausedAnnotation)
+	}
+	model.SetConditionsWithObservedGeneration(xpv2.Condition{
+		Type:               ollamav1alpha1.TypePaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ollamav1alpha1.ReasonPaused,
+		Message:            fmt.Sprintf("Reconciliation is paused with the %s annotation, %d objects would change", ollamav1alpha1.ModelPausedAnnotation, len(model.Status.PendingChanges)),
+	})
+	return nil
+}
+
+// setResumed drops the pending changes of a Model no longer paused, the condition is kept only if it was ever set.
+func (r *Reconciler) setResumed(model *ollamav1alpha1.Model) {
+	model.Status.PendingChanges = nil
+	if cond := model.GetCondition(ollamav1alpha1.TypePaused); cond.Status != corev1.ConditionTrue {
+		return
+	}
+	r.eventRecorderFor(model).NormalEventf("Resuming", "Resumed", "Reconciliation resumed")
+	model.SetConditionsWithObservedGeneration(xpv2.Condition{
+		Type:               ollamav1alpha1.TypePaused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ollamav1alpha1.ReasonResumed,
+	})
+}
+
+// pendingChanges compares the objects generated for the Model with the live ones. Every changed object is summarized
+// with its kind, name and the paths of fields that applying it would change.
+func (r *Reconciler) pendingChanges(ctx context.Context, model *ollamav1alpha1.Model) ([]string, error) {
+	resources, err := Resources(model, r.operator)
+	if err != nil {
+		return nil, fmt.Errorf("while creating resources: %s", err)
+	}
+	var changes []string
+	for _, res := range resources {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(res.GroupVersionKind())
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(res), live); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, errors.Wrapf(err, "failed to fetch %s %s", res.GetKind(), res.GetName())
+			}
+			changes = append(changes, fmt.Sprintf("%s %s: created", res.GetKind(), res.GetName()))
+			continue
+		}
+		if res.GetKind() == "StatefulSet" {
+			existingSts := &appsv1.StatefulSet{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(live.Object, existingSts); err != nil {
+				return nil, err
+			}
+			if err := KeepVolumeClaimTemplateSize(res, existingSts); err != nil {
+				return nil, fmt.Errorf("while preserving volume claim templates: %s", err)
+			}
+		}
+		if paths := changedPaths(res.Object, live.Object, ""); len(paths) > 0 {
+			changes = append(changes, fmt.Sprintf("%s %s: %s", res.GetKind(), res.GetName(), strings.Join(paths, ", ")))
+		}
+	}
+	return changes, nil
+}
+
+// changedPaths returns the paths of fields set in desired that differ in live. Fields set only in live, e.g. defaulted
+// by the API server, are not changes. Lists are compared element by element, a different length changes the whole list.
+func changedPaths(desired, live any, path string) []string {
+	switch desired := desired.(type) {
+	case map[string]any:
+		live, ok := live.(map[string]any)
+		if !ok {
+			return []string{path}
+		}
+		var paths []string
+		for _, key := range slices.Sorted(maps.Keys(desired)) {
+			keyPath := strings.TrimPrefix(path+"."+key, ".")
+			liveValue, found := live[key]
+			if !found {
+				paths = append(paths, keyPath)
+				continue
+			}
+			paths = append(paths, changedPaths(desired[key], liveValue, keyPath)...)
+		}
+		return paths
+	case []any:
+		live, ok := live.([]any)
+		if !ok || len(live) != len(desired) {
+			return []string{path}
+		}
+		var paths []string
+		for i := range desired {
+			paths = append(paths, changedPaths(desired[i], live[i], fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return paths
+	default:
+		if reflect.DeepEqual(normalizeNumber(desired), normalizeNumber(live)) {
+			return nil
+		}
+		return []string{path}
+	}
+}
+
+// normalizeNumber converts numbers to float64, the live objects might be decoded with different number types than generated ones.
+func normalizeNumber(value any) any {
+	switch value := value.(type) {
+	case int64:
+		return float64(value)
+	case int32:
+		return float64(value)
+	case int:
+		return float64(value)
+	default:
+		return value
+	}
+}
+
+// setSuspended reports a Model suspended with spec.suspend.
+func (r *Reconciler) setSuspended(model *ollamav1alpha1.Model) {
+	clearReplicaStatuses(model)
+	if cond := model.GetCondition(ollamav1alpha1.TypeSuspended); cond.Status != corev1.ConditionTrue {
+		r.eventRecorderFor(model).NormalEventf("Suspending", "Suspended", "Scaled to zero, the Model is suspended")
+	}
+	model.SetConditionsWithObservedGeneration(xpv2.Condition{
+		Type:               ollamav1alpha1.TypeSuspended,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ollamav1alpha1.ReasonSuspended,
+		Message:            "Scaled to zero with spec.suspend",
+	})
+	model.SetConditionsWithObservedGeneration(xpv2.Condition{
+		Type:               xpv2.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ollamav1alpha1.ReasonSuspended,
+		Message:            "Model is suspended",
+	})
+}
+
+// setUnsuspended reports a Model no longer suspended, the condition is kept only if it was ever set.
+func (r *Reconciler) setUnsuspended(model *ollamav1alpha1.Model) {
+	if cond := model.GetCondition(ollamav1alpha1.TypeSuspended); cond.Status != corev1.ConditionTrue {
+		return
+	}
+	r.eventRecorderFor(model).NormalEventf("Resuming", "Resumed", "Scaling up, the Model is no longer suspended")
+	model.SetConditionsWithObservedGeneration(xpv2.Condition{
+		Type:               ollamav1alpha1.TypeSuspended,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ollamav1alpha1.ReasonResumed,
+	})
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func Test_changedPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired map[string]any
+		live    map[string]any
+		want    []string
+	}{
+		{
+			name:    "equal",
+			desired: map[string]any{"spec": map[string]any{"replicas": int64(1)}},
+			live:    map[string]any{"spec": map[string]any{"replicas": float64(1)}},
+		},
+		{
+			name:    "fields set only in live are ignored",
+			desired: map[string]any{"spec": map[string]any{"replicas": int64(1)}},
+			live:    map[string]any{"spec": map[string]any{"replicas": int64(1), "revisionHistoryLimit": int64(10)}},
+		},
+		{
+			name:    "changed and missing fields",
+			desired: map[string]any{"spec": map[string]any{"replicas": int64(0), "serviceName": "phi3"}},
+			live:    map[string]any{"spec": map[string]any{"replicas": int64(1)}},
+			want:    []string{"spec.replicas", "spec.serviceName"},
+		},
+		{
+			name: "lists",
+			desired: map[string]any{
+				"containers": []any{map[string]any{"name": "ollama", "image": "ollama/ollama:0.5.0"}},
+				"args":       []any{"--foo"},
+			},
+			live: map[string]any{
+				"containers": []any{map[string]any{"name": "ollama", "image": "ollama/ollama:0.4.0", "terminationMessagePath": "/dev/termination-log"}},
+				"args":       []any{"--foo", "--bar"},
+			},
+			want: []string{"args", "containers[0].image"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, changedPaths(tt.desired, tt.live, ""))
+		})
+	}
+}
+
+func TestReconciler_observePaused(t *testing.T) {
+	model := &ollamav1alpha1.Model{
+		TypeMeta: metav1.TypeMeta{APIVersion: ollamav1alpha1.ModelGroupVersionKind.GroupVersion().String(), Kind: ollamav1alpha1.ModelKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "phi3",
+			Namespace:   "default",
+			UID:         "uid",
+			Annotations: map[string]string{ollamav1alpha1.ModelPausedAnnotation: "true"},
+		},
+		Spec: ollamav1alpha1.ModelSpec{Model: "phi3"},
+	}
+	// only the StatefulSet was applied before the Model got paused
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(statefulSetResource(t, model, Operator{})).Build()
+	r := &Reconciler{client: cli, recorder: record.NewEventRecorderAdapter(record.NewFakeRecorder(100))}
+	ctx := context.Background()
+
+	require.True(t, isPaused(model))
+	require.NoError(t, r.observePaused(ctx, model))
+	require.Equal(t, []string{"Service phi3: created"}, model.Status.PendingChanges)
+
+	model.Spec.OllamaImage = "ollama/ollama:0.5.0"
+	model.Spec.Suspend = true
+	require.NoError(t, r.observePaused(ctx, model))
+	require.Equal(t, []string{
+		"StatefulSet phi3: spec.replicas, spec.template.spec.containers[0].image",
+		"Service phi3: created",
+	}, model.Status.PendingChanges)
+	require.Equal(t, ollamav1alpha1.ReasonPaused, model.GetCondition(ollamav1alpha1.TypePaused).Reason)
+
+	model.SetAnnotations(nil)
+	require.False(t, isPaused(model))
+	r.setResumed(model)
+	require.Nil(t, model.Status.PendingChanges)
+	require.Equal(t, ollamav1alpha1.ReasonResumed, model.GetCondition(ollamav1alpha1.TypePaused).Reason)
+}
+
+func TestReconciler_setSuspended(t *testing.T) {
+	r := &Reconciler{recorder: record.NewEventRecorderAdapter(record.NewFakeRecorder(100))}
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default"},
+		Spec:       ollamav1alpha1.ModelSpec{Model: "phi3", Replicas: ptr.To(int32(2))},
+		Status: ollamav1alpha1.ModelStatus{
+			ReadyReplicas: 2,
+			Models:        []ollamav1alpha1.ModelEntryStatus{{Name: "phi3", Load: &ollamav1alpha1.ModelLoadStatus{LoadedReplicas: 2}}},
+		},
+	}
+	r.setUnsuspended(model)
+	require.Empty(t, model.Status.Conditions, "not set for Models never suspended")
+
+	model.Spec.Suspend = true
+	require.Equal(t, int32(0), desiredReplicas(model))
+	r.setSuspended(model)
+	require.Zero(t, model.Status.ReadyReplicas)
+	require.Nil(t, model.Status.Models[0].Load)
+	require.Equal(t, ollamav1alpha1.ReasonSuspended, model.GetCondition(ollamav1alpha1.TypeSuspended).Reason)
+	require.Equal(t, ollamav1alpha1.ReasonSuspended, model.GetCondition(xpv2.TypeReady).Reason)
+
+	model.Spec.Suspend = false
+	require.Equal(t, int32(2), desiredReplicas(model))
+	r.setUnsuspended(model)
+	require.Equal(t, ollamav1alpha1.ReasonResumed, model.GetCondition(ollamav1alpha1.TypeSuspended).Reason)
+}
@@ -0,0 +1,172 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+const (
+	// updateOnReconcileMinInterval prevents the OnReconcile policy from re-pulling models in a loop, as every finished re-pull requeues the Model
+	updateOnReconcileMinInterval = time.Minute
+	// updateResyncPeriod is how often running re-pulls are checked, in case the event of a finished one was dropped
+	updateResyncPeriod = 30 * time.Second
+)
+
+// updateInterval returns the minimal time between re-pulls of a model, or zero if models are never updated.
+func updateInterval(model *ollamav1alpha1.Model) time.Duration {
+	policy := model.Spec.UpdatePolicy
+	switch {
+	case policy == nil:
+		return 0
+	case policy.Type == ollamav1alpha1.UpdatePolicyOnReconcile:
+		return updateOnReconcileMinInterval
+	case policy.Type == ollamav1alpha1.UpdatePolicyInterval && policy.Interval != nil:
+		return policy.Interval.Duration
+	default:
+		return 0
+	}
+}
+
+// updatable reports whether the model can be updated by a re-pull.
+func updatable(entry ollamav1alpha1.ModelEntry) bool {
+	return entry.Create == nil && entry.Digest == ""
+}
+
+// updating reports whether the model is being re-pulled on any pod.
+func (r *Reconciler) updating(model *ollamav1alpha1.Model, modelName string) bool {
+	return len(r.pulls.find(client.ObjectKeyFromObject(model), func(key pullKey) bool {
+		return key.kind == pullKindUpdate && key.modelName == modelName
+	})) > 0
+}
+
+// observeUpdates re-pulls models that are due according to spec.updatePolicy on every pod, or checks the results of
+// already running re-pulls. Ollama replaces the model only once the new version is fully pulled, so the old one keeps
+// being served in the meantime. It returns the time after which the Model should be reconciled again, or zero.
+func (r *Reconciler) observeUpdates(ctx context.Context, model *ollamav1alpha1.Model, pods []corev1.Pod, desired []ollamav1alpha1.ModelEntry) (time.Duration, error) {
+	modelKey := client.ObjectKeyFromObject(model)
+	interval := updateInterval(model)
+	if interval == 0 {
+		r.pulls.cancelStale(modelKey, func(key pullKey) bool { return key.kind != pullKindUpdate })
+		for i := range model.Status.Models {
+			model.Status.Models[i].Update = nil
+		}
+		return 0, nil
+	}
+	periodic := model.Spec.UpdatePolicy.Type == ollamav1alpha1.UpdatePolicyInterval
+
+	log := ctrl.LoggerFrom(ctx)
+	recorder := r.eventRecorderFor(model)
+	now := r.timeNowFn()
+	var requeueAfter time.Duration
+	requeueIn := func(d time.Duration) {
+		if requeueAfter == 0 || d < requeueAfter {
+			requeueAfter = d
+		}
+	}
+	for _, entry := range desired {
+		entryStatus := model.ModelStatusFor(entry.Name)
+		// seeded models are versioned with spec.source
+		if !updatable(entry) || entryStatus.SeededFrom != "" {
+			entryStatus.Update = nil
+			continue
+		}
+		if entryStatus.Update == nil {
+			entryStatus.Update = &ollamav1alpha1.ModelUpdateStatus{}
+			// the first check is due an interval after the model was pulled, not right after it became ready
+			if entryStatus.ModifiedAt != nil {
+				entryStatus.Update.LastCheckTime = entryStatus.ModifiedAt.DeepCopy()
+			}
+		}
+		update := entryStatus.Update
+
+		keys := r.pulls.find(modelKey, func(key pullKey) bool {
+			return key.kind == pullKindUpdate && key.modelName == entry.Name
+		})
+		if len(keys) == 0 {
+			if update.LastCheckTime != nil && now.Sub(update.LastCheckTime.Time) < interval {
+				if periodic {
+					requeueIn(interval - now.Sub(update.LastCheckTime.Time))
+				}
+				continue
+			}
+			log.V(1).Info("re-pulling model to check for updates", "model", entry.Name)
+			for i := range pods {
+				key := pullKey{model: modelKey, pod: pods[i].GetName(), modelName: entry.Name, kind: pullKindUpdate}
+				r.pulls.start(ctx, key, pullModel(r.ollamaClientFor(model, &pods[i]), entry.Name, insecureRegistry(model)))
+			}
+			requeueIn(updateResyncPeriod)
+			continue
+		}
+
+		var errs []error
+		finished := true
+		for _, key := range keys {
+			p := r.pulls.get(key)
+			if p == nil {
+				// dropped since it was found, it's started again on the next reconcile
+				finished = false
+				break
+			}
+			done, status, err := p.result()
+			if !done {
+				finished = false
+				break
+			}
+			if err == nil && status != "success" {
+				err = fmt.Errorf("pull finished with %q status", status)
+			}
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "pod %q", key.pod))
+			}
+		}
+		if !finished {
+			requeueIn(updateResyncPeriod)
+			continue
+		}
+		for _, key := range keys {
+			r.pulls.forget(key)
+		}
+		update.LastCheckTime = ptr.To(metav1.NewTime(now))
+		if periodic {
+			requeueIn(interval)
+		}
+		if err := errors.Join(errs...); err != nil {
+			update.LastError = err.Error()
+			recorder.WarningEventf("UpdatingModel", "UpdatingModel", "failed to update %q model, keeping the previous version: %s", entry.Name, err)
+			continue
+		}
+
+		// verify the new version before reporting it
+		modelList, err := r.ollamaClientFor(model, &pods[0]).List(ctx)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to list local models")
+		}
+		resp := findListedModel(modelList, entry.Name)
+		if resp == nil {
+			update.LastError = fmt.Sprintf("model %q is missing after the update", entry.Name)
+			recorder.WarningEventf("UpdatingModel", "UpdatingModel", "failed to update %q model: %s", entry.Name, update.LastError)
+			continue
+		}
+		update.LastError = ""
+		if resp.Digest == entryStatus.Digest {
+			continue
+		}
+		recorder.NormalEventf("UpdatingModel", "UpdatedModel", "Updated %q model, digest changed from %s to %s", entry.Name, entryStatus.Digest, resp.Digest)
+		update.PreviousDigest = entryStatus.Digest
+		update.LastUpdateTime = ptr.To(metav1.NewTime(now))
+		entryStatus.Digest = resp.Digest
+		entryStatus.Size = resp.Size
+		entryStatus.ModifiedAt = ptr.To(metav1.NewTime(resp.ModifiedAt))
+	}
+	return requeueAfter, nil
+}
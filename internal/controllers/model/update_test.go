@@ -0,0 +1,119 @@
+package model
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ollama/ollama/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/ollamaclient"
+)
+
+func TestReconciler_observeUpdates(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	var pulled []string
+	r := &Reconciler{
+		recorder:  record.NewEventRecorderAdapter(record.NewFakeRecorder(100)),
+		pulls:     newPullManager(time.Now),
+		timeNowFn: func() time.Time { return now },
+		ollamaClientProvider: &ollamaclient.TestOllamaClientProvider{
+			Client: &ollamaclient.TestOllamaClient{
+				OnPull: func(ctx context.Context, req *api.PullRequest, progressFunc api.PullProgressFunc) error {
+					mu.Lock()
+					pulled = append(pulled, req.Model)
+					mu.Unlock()
+					return progressFunc(api.ProgressResponse{Status: "success"})
+				},
+				OnList: func(ctx context.Context) (*api.ListResponse, error) {
+					return &api.ListResponse{Models: []api.ListModelResponse{{Model: "llama3.1:latest", Digest: "new"}}}, nil
+				},
+			},
+		},
+	}
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: ollamav1alpha1.ModelSpec{
+			Model: "llama3.1",
+			UpdatePolicy: &ollamav1alpha1.UpdatePolicy{
+				Type:     ollamav1alpha1.UpdatePolicyInterval,
+				Interval: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+		Status: ollamav1alpha1.ModelStatus{Models: []ollamav1alpha1.ModelEntryStatus{{Name: "llama3.1", Digest: "old"}}},
+	}
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-1"}},
+	}
+
+	requeueAfter, err := r.observeUpdates(context.Background(), model, pods, model.DesiredModels())
+	require.NoError(t, err)
+	require.Equal(t, updateResyncPeriod, requeueAfter)
+	require.True(t, r.updating(model, "llama3.1"))
+	require.Eventually(t, func() bool {
+		for _, key := range r.pulls.find(client.ObjectKeyFromObject(model), func(pullKey) bool { return true }) {
+			if finished, _, _ := r.pulls.get(key).result(); !finished {
+				return false
+			}
+		}
+		return true
+	}, time.Second, 10*time.Millisecond)
+
+	requeueAfter, err = r.observeUpdates(context.Background(), model, pods, model.DesiredModels())
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, requeueAfter)
+	require.False(t, r.updating(model, "llama3.1"))
+	require.Equal(t, []string{"llama3.1", "llama3.1"}, pulled)
+	entryStatus := model.ModelStatusFor("llama3.1")
+	require.Equal(t, "new", entryStatus.Digest)
+	require.Equal(t, &ollamav1alpha1.ModelUpdateStatus{
+		LastCheckTime:  &metav1.Time{Time: now},
+		LastUpdateTime: &metav1.Time{Time: now},
+		PreviousDigest: "old",
+	}, entryStatus.Update)
+
+	now = now.Add(10 * time.Minute)
+	requeueAfter, err = r.observeUpdates(context.Background(), model, pods, model.DesiredModels())
+	require.NoError(t, err)
+	require.Equal(t, 50*time.Minute, requeueAfter)
+	require.False(t, r.updating(model, "llama3.1"), "model is not due for an update yet")
+}
+
+func TestReconciler_observeUpdates_firstCheckAfterPull(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := &Reconciler{
+		recorder:  record.NewEventRecorderAdapter(record.NewFakeRecorder(100)),
+		pulls:     newPullManager(time.Now),
+		timeNowFn: func() time.Time { return now },
+	}
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: ollamav1alpha1.ModelSpec{
+			Model: "llama3.1",
+			UpdatePolicy: &ollamav1alpha1.UpdatePolicy{
+				Type:     ollamav1alpha1.UpdatePolicyInterval,
+				Interval: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+		Status: ollamav1alpha1.ModelStatus{Models: []ollamav1alpha1.ModelEntryStatus{{
+			Name:       "llama3.1",
+			Digest:     "old",
+			ModifiedAt: &metav1.Time{Time: now.Add(-10 * time.Minute)},
+		}}},
+	}
+
+	requeueAfter, err := r.observeUpdates(context.Background(), model, []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "test-0"}}}, model.DesiredModels())
+	require.NoError(t, err)
+	require.Equal(t, 50*time.Minute, requeueAfter)
+	require.False(t, r.updating(model, "llama3.1"), "just pulled")
+	require.Equal(t, &metav1.Time{Time: now.Add(-10 * time.Minute)}, model.ModelStatusFor("llama3.1").Update.LastCheckTime)
+}
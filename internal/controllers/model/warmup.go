@@ -0,0 +1,103 @@
+package model
+
+import (
+	"context"
+	"slices"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	ollamaapi "github.com/ollama/ollama/api"
+	ollamamodel "github.com/ollama/ollama/types/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+// sameModel reports whether both names refer to the same model, e.g. "phi3" and "phi3:latest".
+func sameModel(a, b string) bool {
+	return ollamamodel.ParseName(a).EqualFold(ollamamodel.ParseName(b))
+}
+
+// observeLoadState reports which of the desired models are loaded into memory. With spec.warmUp it also loads models that
+// are not loaded yet, until the Model becomes Ready, and returns the keys of loads that are still in progress.
+func (r *Reconciler) observeLoadState(ctx context.Context, model *ollamav1alpha1.Model, pods []corev1.Pod, desired []ollamav1alpha1.ModelEntry) ([]pullKey, error) {
+	// once Ready, models unloaded after their keep alive expired are not loaded again
+	warmUp := model.Spec.WarmUp && model.GetCondition(xpv2.TypeReady).Status != corev1.ConditionTrue
+	modelKey := client.ObjectKeyFromObject(model)
+
+	loads := make(map[string]*ollamav1alpha1.ModelLoadStatus, len(desired))
+	for _, entry := range desired {
+		loads[entry.Name] = &ollamav1alpha1.ModelLoadStatus{}
+	}
+	var loading []pullKey
+	for i := range pods {
+		running, err := r.ollamaClientFor(model, &pods[i]).ListRunning(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list running models")
+		}
+		for _, entry := range desired {
+			key := pullKey{model: modelKey, pod: pods[i].GetName(), modelName: entry.Name, kind: pullKindLoad}
+			idx := slices.IndexFunc(running.Models, func(resp ollamaapi.ProcessModelResponse) bool {
+				return sameModel(resp.Model, entry.Name)
+			})
+			if idx < 0 {
+				if !warmUp {
+					r.pulls.forget(key)
+					continue
+				}
+				inProgress, err := r.observeLoad(ctx, &pods[i], model, key)
+				if err != nil {
+					return nil, err
+				}
+				if inProgress {
+					loading = append(loading, key)
+				}
+				continue
+			}
+
+			r.pulls.forget(key)
+			load := loads[entry.Name]
+			if load.LoadedReplicas == 0 {
+				resp := running.Models[idx]
+				load.SizeVRAM = resp.SizeVRAM
+				load.SizeRAM = resp.Size - resp.SizeVRAM
+				if !resp.ExpiresAt.IsZero() {
+					load.ExpiresAt = ptr.To(metav1.NewTime(resp.ExpiresAt))
+				}
+			}
+			load.LoadedReplicas++
+		}
+	}
+	for _, entry := range desired {
+		model.ModelStatusFor(entry.Name).Load = loads[entry.Name]
+	}
+	return loading, nil
+}
+
+// observeLoad starts loading the model into memory in the background, or checks the result of the already running load.
+// It returns true while the load is in progress.
+func (r *Reconciler) observeLoad(ctx context.Context, pod *corev1.Pod, model *ollamav1alpha1.Model, key pullKey) (bool, error) {
+	recorder := r.eventRecorderFor(model)
+	p := r.pulls.get(key)
+	if p == nil {
+		ctrl.LoggerFrom(ctx).V(1).Info("started loading ollama model", "model", key.modelName, "pod", key.pod)
+		recorder.NormalEventf("LoadingModel", "LoadingModel", "Loading %q model on pod %q", key.modelName, key.pod)
+		r.pulls.start(ctx, key, loadModel(r.ollamaClientFor(model, pod), key.modelName))
+		return true, nil
+	}
+
+	finished, _, err := p.result()
+	if !finished {
+		return true, nil
+	}
+	r.pulls.forget(key)
+	if err != nil {
+		recorder.WarningEventf("LoadingModel", "LoadingModel", "failed to load %q model on pod %q: %s", key.modelName, key.pod, err)
+		return false, errors.Wrapf(err, "failed to load %q model on pod %q", key.modelName, key.pod)
+	}
+	return false, nil
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ollama/ollama/api"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/ollamaclient"
+)
+
+func TestReconciler_observeLoadState(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	loaded := api.ProcessModelResponse{Model: "phi3:latest", Size: 3000, SizeVRAM: 2000, ExpiresAt: expiresAt}
+	running := map[int][]api.ProcessModelResponse{0: {loaded}}
+	listRunningCalls := 0
+	generated := make(chan string, 1)
+
+	r := &Reconciler{
+		recorder: record.NewEventRecorderAdapter(record.NewFakeRecorder(100)),
+		pulls:    newPullManager(time.Now),
+		ollamaClientProvider: &ollamaclient.TestOllamaClientProvider{
+			Client: &ollamaclient.TestOllamaClient{
+				OnListRunning: func(ctx context.Context) (*api.ProcessResponse, error) {
+					defer func() { listRunningCalls++ }()
+					return &api.ProcessResponse{Models: running[listRunningCalls]}, nil
+				},
+				OnGenerate: func(ctx context.Context, req *api.GenerateRequest, fn api.GenerateResponseFunc) error {
+					require.Empty(t, req.Prompt)
+					generated <- req.Model
+					return fn(api.GenerateResponse{Model: req.Model, Done: true, DoneReason: "load"})
+				},
+			},
+		},
+	}
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       ollamav1alpha1.ModelSpec{Model: "phi3", WarmUp: true},
+		Status:     ollamav1alpha1.ModelStatus{Models: []ollamav1alpha1.ModelEntryStatus{{Name: "phi3"}}},
+	}
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-1"}},
+	}
+
+	loading, err := r.observeLoadState(context.Background(), model, pods, model.DesiredModels())
+	require.NoError(t, err)
+	require.Len(t, loading, 1)
+	require.Equal(t, "test-1", loading[0].pod)
+	require.Equal(t, "phi3", <-generated)
+	require.Equal(t, &ollamav1alpha1.ModelLoadStatus{
+		LoadedReplicas: 1,
+		SizeVRAM:       2000,
+		SizeRAM:        1000,
+		ExpiresAt:      &metav1.Time{Time: expiresAt},
+	}, model.ModelStatusFor("phi3").Load)
+
+	require.Eventually(t, func() bool {
+		finished, _, _ := r.pulls.get(loading[0]).result()
+		return finished
+	}, time.Second, 10*time.Millisecond)
+	running[2] = []api.ProcessModelResponse{loaded}
+	running[3] = []api.ProcessModelResponse{loaded}
+	loading, err = r.observeLoadState(context.Background(), model, pods, model.DesiredModels())
+	require.NoError(t, err)
+	require.Empty(t, loading)
+	require.Equal(t, int32(2), model.ModelStatusFor("phi3").Load.LoadedReplicas)
+}
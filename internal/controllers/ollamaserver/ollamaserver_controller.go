@@ -0,0 +1,235 @@
+package ollamaserver
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	modelcontroller "aerf.io/ollama-operator/internal/controllers/model"
+	"aerf.io/ollama-operator/internal/defaults"
+	"aerf.io/ollama-operator/internal/eventrecorder"
+
+	"aerf.io/k8sutils/utilreconcilers"
+)
+
+type Reconciler struct {
+	client   client.Client
+	recorder events.EventRecorder
+	operator modelcontroller.Operator
+}
+
+func SetupWithManager(mgr ctrl.Manager, tp trace.TracerProvider, operator modelcontroller.Operator) error {
+	r := &Reconciler{client: mgr.GetClient(), recorder: mgr.GetEventRecorder("ollama-operator.ollamaserver-controller"), operator: operator}
+	reconciler := reconcile.AsReconciler(mgr.GetClient(), r)
+	reconciler = utilreconcilers.NewWithTracingReconciler(
+		reconciler,
+		tp.Tracer("ollamaserver-controller", trace.WithInstrumentationAttributes(attribute.Stringer("controller-gvk", ollamav1alpha1.OllamaServerGroupVersionKind))),
+	)
+	reconciler = utilreconcilers.RequeueOnConflict(reconciler)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ollamav1alpha1.OllamaServer{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&corev1.Service{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		// PVCs are owned by the StatefulSet, map them back to the OllamaServer using the label
+		WatchesRawSource(source.Kind(mgr.GetCache(), &corev1.PersistentVolumeClaim{}, handler.TypedEnqueueRequestsFromMapFunc(modelcontroller.EnqueueServerFromLabel[*corev1.PersistentVolumeClaim]))).
+		// hosted Models are reported in status and block deletion of the OllamaServer
+		WatchesRawSource(source.Kind(mgr.GetCache(), &ollamav1alpha1.Model{}, handler.TypedEnqueueRequestsFromMapFunc(enqueueReferencedServer))).
+		WithLogConstructor(func(req *reconcile.Request) logr.Logger {
+			log := mgr.GetLogger().WithValues("controller", "ollamaserver-controller")
+			if req == nil {
+				return log
+			}
+			return log.WithValues("name", req.Name, "namespace", req.Namespace)
+		}).
+		Complete(reconciler)
+}
+
+// enqueueReferencedServer maps every event of a Model to the OllamaServer it references, status changes included,
+// so readiness of hosted Models is refreshed without a change of their spec.
+func enqueueReferencedServer(_ context.Context, model *ollamav1alpha1.Model) []reconcile.Request {
+	if model.Spec.ServerRef == nil {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: model.GetNamespace(), Name: model.Spec.ServerRef.Name}}}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, server *ollamav1alpha1.OllamaServer) (result ctrl.Result, retErr error) {
+	if server.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, r.finalize(ctx, server)
+	}
+
+	defer func() {
+		server.Status.ObservedGeneration = server.GetGeneration()
+		server.Status.OllamaImage = cmp.Or(server.Spec.OllamaImage, defaults.OllamaImage)
+		if retErr != nil {
+			server.SetConditionsWithObservedGeneration(xpv2.ReconcileError(retErr))
+			server.SetConditionsWithObservedGeneration(xpv2.Unavailable())
+		} else {
+			server.SetConditionsWithObservedGeneration(xpv2.ReconcileSuccess())
+		}
+
+		patchErr := r.client.Status().Update(ctx, server)
+		if patchErr != nil {
+			retErr = errors.Join(retErr, fmt.Errorf("while patching status: %s", patchErr))
+		}
+	}()
+
+	log := ctrl.LoggerFrom(ctx)
+	log.V(1).Info("Reconciling OllamaServer", "object", server)
+
+	if err := r.ensureFinalizer(ctx, server); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	resources, err := modelcontroller.ServerResources(server, r.operator)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("while creating resources: %s", err)
+	}
+	existingSts := &appsv1.StatefulSet{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(server), existingSts); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to fetch statefulset")
+	}
+	for _, res := range resources {
+		if res.GetKind() == "StatefulSet" && existingSts.GetUID() != "" {
+			if err := modelcontroller.KeepVolumeClaimTemplateSize(res, existingSts); err != nil {
+				return ctrl.Result{}, fmt.Errorf("while preserving volume claim templates: %s", err)
+			}
+		}
+		log.V(1).Info("Applying object", "object", res)
+		if err := r.client.Apply(ctx, client.ApplyConfigurationFromUnstructured(res), client.ForceOwnership); err != nil {
+			return ctrl.Result{}, fmt.Errorf("while applying %s %s: %s", res.GetKind(), res.GetName(), err)
+		}
+	}
+
+	if err := modelcontroller.PruneNetworkPolicy(ctx, r.client, server, server.Spec.NetworkPolicy); err != nil {
+		return ctrl.Result{}, err
+	}
+	storageCond, err := modelcontroller.ExpandServerVolumes(ctx, r.client, eventrecorder.New(r.recorder, server), server)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if storageCond != nil {
+		server.SetConditionsWithObservedGeneration(*storageCond)
+	}
+
+	modelList := &ollamav1alpha1.ModelList{}
+	if err := r.client.List(ctx, modelList, client.InNamespace(server.GetNamespace())); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to list Models")
+	}
+	server.Status.Models = hostedModels(server, modelList.Items)
+
+	sts := &appsv1.StatefulSet{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(server), sts); err != nil {
+		if apierrors.IsNotFound(err) {
+			server.SetConditionsWithObservedGeneration(xpv2.Creating())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, errors.Wrap(err, "failed to fetch statefulset to check its readiness")
+	}
+	server.Status.ReadyReplicas = sts.Status.ReadyReplicas
+
+	readyMsg, ready, err := modelcontroller.IsStatefulSetReady(sts)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !ready {
+		server.SetConditionsWithObservedGeneration(xpv2.Unavailable().WithMessage(readyMsg))
+		return ctrl.Result{}, nil
+	}
+	server.SetConditionsWithObservedGeneration(xpv2.Available())
+	return ctrl.Result{}, nil
+}
+
+// ensureFinalizer adds the finalizer blocking deletion of the OllamaServer while Models reference it.
+func (r *Reconciler) ensureFinalizer(ctx context.Context, server *ollamav1alpha1.OllamaServer) error {
+	if controllerutil.ContainsFinalizer(server, ollamav1alpha1.OllamaServerInUseFinalizer) {
+		return nil
+	}
+	// patch a copy so the server response doesn't overwrite the in-memory object
+	patched := server.DeepCopy()
+	controllerutil.AddFinalizer(patched, ollamav1alpha1.OllamaServerInUseFinalizer)
+	if err := r.client.Patch(ctx, patched, client.MergeFromWithOptions(server, client.MergeFromWithOptimisticLock{})); err != nil {
+		return errors.Wrap(err, "failed to add finalizer")
+	}
+	server.SetFinalizers(patched.GetFinalizers())
+	server.SetResourceVersion(patched.GetResourceVersion())
+	return nil
+}
+
+// finalize removes the finalizer once no Model references the OllamaServer. Models being deleted count as well, they
+// may still need the server to clean up their models. Until then the Ready condition lists the remaining Models, the
+// OllamaServer is requeued by the Model watch once they're gone.
+func (r *Reconciler) finalize(ctx context.Context, server *ollamav1alpha1.OllamaServer) error {
+	if !controllerutil.ContainsFinalizer(server, ollamav1alpha1.OllamaServerInUseFinalizer) {
+		return nil
+	}
+	modelList := &ollamav1alpha1.ModelList{}
+	if err := r.client.List(ctx, modelList, client.InNamespace(server.GetNamespace())); err != nil {
+		return errors.Wrap(err, "failed to list Models")
+	}
+	if referencing := referencingModels(server, modelList.Items); len(referencing) > 0 {
+		ctrl.LoggerFrom(ctx).V(1).Info("OllamaServer is still referenced, waiting with its deletion", "models", referencing)
+		server.SetConditionsWithObservedGeneration(xpv2.Deleting().WithMessage(
+			fmt.Sprintf("Waiting for Models referencing the OllamaServer to be deleted: %s", strings.Join(referencing, ", "))))
+		return errors.Wrap(r.client.Status().Update(ctx, server), "failed to update status")
+	}
+
+	patch := client.MergeFromWithOptions(server.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	controllerutil.RemoveFinalizer(server, ollamav1alpha1.OllamaServerInUseFinalizer)
+	return errors.Wrap(r.client.Patch(ctx, server, patch), "failed to remove finalizer")
+}
+
+// referencingModels returns names of all Models referencing the OllamaServer, sorted.
+func referencingModels(server *ollamav1alpha1.OllamaServer, models []ollamav1alpha1.Model) []string {
+	var names []string
+	for _, model := range models {
+		if model.Spec.ServerRef != nil && model.Spec.ServerRef.Name == server.GetName() {
+			names = append(names, model.GetName())
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// hostedModels returns Models referencing the OllamaServer, sorted by name.
+func hostedModels(server *ollamav1alpha1.OllamaServer, models []ollamav1alpha1.Model) []ollamav1alpha1.HostedModel {
+	var hosted []ollamav1alpha1.HostedModel
+	for _, model := range models {
+		if model.Spec.ServerRef == nil || model.Spec.ServerRef.Name != server.GetName() || model.GetDeletionTimestamp() != nil {
+			continue
+		}
+		entry := ollamav1alpha1.HostedModel{
+			Name:  model.GetName(),
+			Ready: model.GetCondition(xpv2.TypeReady).Equal(xpv2.Available()),
+		}
+		for _, desired := range model.DesiredModels() {
+			entry.Models = append(entry.Models, desired.Name)
+		}
+		hosted = append(hosted, entry)
+	}
+	slices.SortFunc(hosted, func(a, b ollamav1alpha1.HostedModel) int { return strings.Compare(a.Name, b.Name) })
+	return hosted
+}
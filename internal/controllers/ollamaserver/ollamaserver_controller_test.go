@@ -0,0 +1,180 @@
+package ollamaserver
+
+import (
+	"context"
+	"testing"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func Test_hostedModels(t *testing.T) {
+	server := &ollamav1alpha1.OllamaServer{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"}}
+	ready := ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "default"},
+		Spec: ollamav1alpha1.ModelSpec{
+			ServerRef: &corev1.LocalObjectReference{Name: "shared"},
+			Model:     "smollm:135m",
+			Models:    []ollamav1alpha1.ModelEntry{{Name: "granite3-moe:1b"}},
+		},
+	}
+	ready.Status.SetConditions(xpv2.Available())
+	models := []ollamav1alpha1.Model{
+		ready,
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pulling", Namespace: "default"},
+			Spec:       ollamav1alpha1.ModelSpec{ServerRef: &corev1.LocalObjectReference{Name: "shared"}, Model: "phi3"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "default"},
+			Spec:       ollamav1alpha1.ModelSpec{Model: "llama3.1"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+			Spec:       ollamav1alpha1.ModelSpec{ServerRef: &corev1.LocalObjectReference{Name: "other"}, Model: "gemma2"},
+		},
+	}
+
+	want := []ollamav1alpha1.HostedModel{
+		{Name: "pulling", Models: []string{"phi3"}},
+		{Name: "small", Models: []string{"smollm:135m", "granite3-moe:1b"}, Ready: true},
+	}
+	if diff := cmp.Diff(want, hostedModels(server, models)); diff != "" {
+		t.Errorf("hostedModels() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReconciler_Reconcile_expandsVolumes(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(s))
+	require.NoError(t, ollamav1alpha1.AddToScheme(s))
+	server := &ollamav1alpha1.OllamaServer{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ollamav1alpha1.OllamaServerGroupVersionKind.GroupVersion().String(), Kind: ollamav1alpha1.OllamaServerKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default", Generation: 2},
+		Spec:       ollamav1alpha1.OllamaServerSpec{Storage: &ollamav1alpha1.Storage{Size: ptr.To(resource.MustParse("30Gi"))}},
+	}
+	pvcSpec := func() corev1.PersistentVolumeClaimSpec {
+		return corev1.PersistentVolumeClaimSpec{Resources: corev1.VolumeResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")},
+		}}
+	}
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default", UID: "sts-uid"},
+		Spec: appsv1.StatefulSetSpec{
+			// defaulted by the API server
+			UpdateStrategy:       appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "shared-ollama-root"}, Spec: pvcSpec()}},
+		},
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared-ollama-root-shared-0", Namespace: "default"},
+		Spec:       pvcSpec(),
+	}
+	cli := fake.NewClientBuilder().WithScheme(s).WithObjects(server, sts, pvc).WithStatusSubresource(&ollamav1alpha1.OllamaServer{}).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{client: client.WithFieldOwner(cli, "ollama-operator"), recorder: record.NewEventRecorderAdapter(recorder)}
+
+	_, err := r.Reconcile(context.Background(), server)
+	require.NoError(t, err)
+
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKeyFromObject(sts), sts))
+	require.Equal(t, "20Gi", ptr.To(sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage]).String(),
+		"volume claim templates are immutable")
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKeyFromObject(pvc), pvc))
+	require.Equal(t, "30Gi", ptr.To(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).String())
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKeyFromObject(server), server))
+	cond := server.GetCondition(ollamav1alpha1.TypeStorageExpanded)
+	require.Equal(t, ollamav1alpha1.ReasonExpanding, cond.Reason)
+	require.Contains(t, <-recorder.Events, "Expanding PVC shared-ollama-root-shared-0 from 20Gi to 30Gi")
+}
+
+func newServerReconciler(t *testing.T, objs ...client.Object) (*Reconciler, client.Client) {
+	t.Helper()
+	s := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(s))
+	require.NoError(t, ollamav1alpha1.AddToScheme(s))
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"},
+		// defaulted by the API server
+		Spec: appsv1.StatefulSetSpec{UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType}},
+	}
+	cli := fake.NewClientBuilder().WithScheme(s).WithObjects(append(objs, sts)...).
+		WithStatusSubresource(&ollamav1alpha1.OllamaServer{}, &ollamav1alpha1.Model{}).Build()
+	return &Reconciler{client: client.WithFieldOwner(cli, "ollama-operator"), recorder: record.NewEventRecorderAdapter(record.NewFakeRecorder(10))}, cli
+}
+
+func TestReconciler_Reconcile_blocksDeletionWhileReferenced(t *testing.T) {
+	server := &ollamav1alpha1.OllamaServer{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ollamav1alpha1.OllamaServerGroupVersionKind.GroupVersion().String(), Kind: ollamav1alpha1.OllamaServerKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"},
+	}
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default"},
+		Spec:       ollamav1alpha1.ModelSpec{ServerRef: &corev1.LocalObjectReference{Name: "shared"}, Model: "phi3"},
+	}
+	r, cli := newServerReconciler(t, server, model)
+	ctx := context.Background()
+
+	_, err := r.Reconcile(ctx, server)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(server), server))
+	require.Contains(t, server.GetFinalizers(), ollamav1alpha1.OllamaServerInUseFinalizer)
+
+	require.NoError(t, cli.Delete(ctx, server))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(server), server))
+	_, err = r.Reconcile(ctx, server)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(server), server), "kept while a Model references it")
+	cond := server.GetCondition(xpv2.TypeReady)
+	require.Equal(t, xpv2.ReasonDeleting, cond.Reason)
+	require.Contains(t, cond.Message, "phi3")
+
+	require.NoError(t, cli.Delete(ctx, model))
+	_, err = r.Reconcile(ctx, server)
+	require.NoError(t, err)
+	require.True(t, apierrors.IsNotFound(cli.Get(ctx, client.ObjectKeyFromObject(server), server)))
+}
+
+func TestReconciler_Reconcile_refreshesReadinessOfHostedModels(t *testing.T) {
+	server := &ollamav1alpha1.OllamaServer{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ollamav1alpha1.OllamaServerGroupVersionKind.GroupVersion().String(), Kind: ollamav1alpha1.OllamaServerKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"},
+	}
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "phi3", Namespace: "default", Generation: 1},
+		Spec:       ollamav1alpha1.ModelSpec{ServerRef: &corev1.LocalObjectReference{Name: "shared"}, Model: "phi3"},
+	}
+	r, cli := newServerReconciler(t, server, model)
+	ctx := context.Background()
+
+	_, err := r.Reconcile(ctx, server)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(server), server))
+	require.Equal(t, []ollamav1alpha1.HostedModel{{Name: "phi3", Models: []string{"phi3"}}}, server.Status.Models)
+
+	// only the status of the Model changes, its generation stays the same
+	model.Status.SetConditions(xpv2.Available())
+	require.NoError(t, cli.Status().Update(ctx, model))
+	requests := enqueueReferencedServer(ctx, model)
+	require.Equal(t, []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(server)}}, requests)
+
+	_, err = r.Reconcile(ctx, server)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(server), server))
+	require.Equal(t, []ollamav1alpha1.HostedModel{{Name: "phi3", Models: []string{"phi3"}, Ready: true}}, server.Status.Models)
+}
@@ -0,0 +1,95 @@
+package prompt
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+// modelRefIndex indexes Prompts by namespace/name of the referenced Model, so Prompts from all namespaces are found
+// when a Model or a ModelAccessGrant changes.
+const modelRefIndex = "spec.modelRef"
+
+func modelRefKey(prompt *ollamav1alpha1.Prompt) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: cmp.Or(prompt.Spec.ModelRef.Namespace, prompt.GetNamespace()),
+		Name:      prompt.Spec.ModelRef.Name,
+	}
+}
+
+func indexModelRef(obj client.Object) []string {
+	prompt, ok := obj.(*ollamav1alpha1.Prompt)
+	if !ok {
+		return nil
+	}
+	return []string{modelRefKey(prompt).String()}
+}
+
+// promptsForModel returns requests for Prompts referencing the Model, including Prompts from other namespaces.
+func (r *Reconciler) promptsForModel(ctx context.Context, model types.NamespacedName) ([]reconcile.Request, error) {
+	promptList := &ollamav1alpha1.PromptList{}
+	if err := r.client.List(ctx, promptList, client.MatchingFields{modelRefIndex: model.String()}); err != nil {
+		return nil, errors.Wrap(err, "unable to list prompts")
+	}
+	requests := make([]reconcile.Request, 0, len(promptList.Items))
+	for _, prompt := range promptList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&prompt)})
+	}
+	return requests, nil
+}
+
+// promptsForGrant returns requests for Prompts from namespaces listed in the grant that reference Models it covers.
+func (r *Reconciler) promptsForGrant(ctx context.Context, grant *ollamav1alpha1.ModelAccessGrant) ([]reconcile.Request, error) {
+	var modelNames []string
+	for _, to := range grant.Spec.To {
+		if to.Name != "" {
+			modelNames = append(modelNames, to.Name)
+			continue
+		}
+		modelList := &ollamav1alpha1.ModelList{}
+		if err := r.client.List(ctx, modelList, client.InNamespace(grant.GetNamespace())); err != nil {
+			return nil, errors.Wrap(err, "unable to list models")
+		}
+		for _, model := range modelList.Items {
+			modelNames = append(modelNames, model.GetName())
+		}
+	}
+	slices.Sort(modelNames)
+
+	var requests []reconcile.Request
+	for _, name := range slices.Compact(modelNames) {
+		modelRequests, err := r.promptsForModel(ctx, types.NamespacedName{Namespace: grant.GetNamespace(), Name: name})
+		if err != nil {
+			return nil, err
+		}
+		for _, req := range modelRequests {
+			if slices.ContainsFunc(grant.Spec.From, func(f ollamav1alpha1.ModelAccessGrantFrom) bool { return f.Namespace == req.Namespace }) {
+				requests = append(requests, req)
+			}
+		}
+	}
+	return requests, nil
+}
+
+// modelAccessAllowed returns true if the Model is in the namespace of the Prompt, or a ModelAccessGrant in the namespace
+// of the Model shares it with the namespace of the Prompt.
+func (r *Reconciler) modelAccessAllowed(ctx context.Context, prompt *ollamav1alpha1.Prompt, model types.NamespacedName) (bool, error) {
+	if model.Namespace == prompt.GetNamespace() {
+		return true, nil
+	}
+	grantList := &ollamav1alpha1.ModelAccessGrantList{}
+	if err := r.client.List(ctx, grantList, client.InNamespace(model.Namespace)); err != nil {
+		return false, fmt.Errorf("failed to list model access grants: %w", err)
+	}
+	return slices.ContainsFunc(grantList.Items, func(grant ollamav1alpha1.ModelAccessGrant) bool {
+		return grant.Allows(prompt.GetNamespace(), model.Name)
+	}), nil
+}
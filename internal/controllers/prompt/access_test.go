@@ -0,0 +1,88 @@
+package prompt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func TestReconciler_modelAccess(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, ollamav1alpha1.AddToScheme(scheme))
+
+	prompt := func(namespace, name string, ref ollamav1alpha1.ModelRef) *ollamav1alpha1.Prompt {
+		return &ollamav1alpha1.Prompt{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       ollamav1alpha1.PromptSpec{ModelRef: ref},
+		}
+	}
+	local := prompt("models", "local", ollamav1alpha1.ModelRef{Name: "phi3"})
+	granted := prompt("team-a", "granted", ollamav1alpha1.ModelRef{Namespace: "models", Name: "phi3"})
+	notGranted := prompt("team-b", "not-granted", ollamav1alpha1.ModelRef{Namespace: "models", Name: "phi3"})
+	otherModel := prompt("team-a", "other-model", ollamav1alpha1.ModelRef{Namespace: "models", Name: "llama3"})
+	grant := &ollamav1alpha1.ModelAccessGrant{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "models", Name: "team-a"},
+		Spec: ollamav1alpha1.ModelAccessGrantSpec{
+			From: []ollamav1alpha1.ModelAccessGrantFrom{{Namespace: "team-a"}},
+			To:   []ollamav1alpha1.ModelAccessGrantTo{{Name: "phi3"}},
+		},
+	}
+	r := &Reconciler{client: fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&ollamav1alpha1.Prompt{}, modelRefIndex, indexModelRef).
+		WithObjects(local, granted, notGranted, otherModel, grant,
+			&ollamav1alpha1.Model{ObjectMeta: metav1.ObjectMeta{Namespace: "models", Name: "phi3"}},
+			&ollamav1alpha1.Model{ObjectMeta: metav1.ObjectMeta{Namespace: "models", Name: "llama3"}},
+		).
+		Build()}
+	ctx := context.Background()
+
+	t.Run("Prompts from all namespaces are mapped to the Model", func(t *testing.T) {
+		requests, err := r.promptsForModel(ctx, types.NamespacedName{Namespace: "models", Name: "phi3"})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []reconcile.Request{
+			{NamespacedName: types.NamespacedName{Namespace: "models", Name: "local"}},
+			{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "granted"}},
+			{NamespacedName: types.NamespacedName{Namespace: "team-b", Name: "not-granted"}},
+		}, requests)
+	})
+
+	t.Run("grants are mapped to Prompts from granted namespaces", func(t *testing.T) {
+		requests, err := r.promptsForGrant(ctx, grant)
+		require.NoError(t, err)
+		require.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "granted"}}}, requests)
+
+		all := grant.DeepCopy()
+		all.Spec.To = []ollamav1alpha1.ModelAccessGrantTo{{}}
+		requests, err = r.promptsForGrant(ctx, all)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []reconcile.Request{
+			{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "granted"}},
+			{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "other-model"}},
+		}, requests)
+	})
+
+	for _, tt := range []struct {
+		prompt *ollamav1alpha1.Prompt
+		want   bool
+	}{
+		{prompt: local, want: true},
+		{prompt: granted, want: true},
+		{prompt: notGranted, want: false},
+		{prompt: otherModel, want: false},
+	} {
+		t.Run("access of "+tt.prompt.GetName(), func(t *testing.T) {
+			allowed, err := r.modelAccessAllowed(ctx, tt.prompt, modelRefKey(tt.prompt))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, allowed)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package prompt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func TestReconciler_wakesModel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, ollamav1alpha1.AddToScheme(scheme))
+
+	prompt := &ollamav1alpha1.Prompt{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "prompt"},
+		Spec:       ollamav1alpha1.PromptSpec{ModelRef: ollamav1alpha1.ModelRef{Name: "phi3"}, Prompt: "Hi"},
+	}
+	model := &ollamav1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "phi3"},
+		Spec:       ollamav1alpha1.ModelSpec{Model: "phi3", Idle: &metav1.Duration{Duration: time.Hour}},
+		Status:     ollamav1alpha1.ModelStatus{ScaledToZero: true},
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&ollamav1alpha1.Prompt{}, &ollamav1alpha1.Model{}).
+		WithObjects(prompt, model).
+		Build()
+	r := &Reconciler{client: cli}
+	ctx := context.Background()
+
+	_, err := r.Reconcile(ctx, prompt)
+	require.NoError(t, err)
+	cond := prompt.GetCondition(xpv2.TypeReady)
+	require.Equal(t, corev1.ConditionFalse, cond.Status)
+	require.Equal(t, ollamav1alpha1.ReasonWakingModel, cond.Reason)
+
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(model), model))
+	lastUsed, err := time.Parse(time.RFC3339, model.GetAnnotations()[ollamav1alpha1.ModelLastUsedAnnotation])
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), lastUsed, time.Minute)
+
+	// the Model is scaling up, the Prompt keeps waiting for it
+	model.Status.ScaledToZero = false
+	require.NoError(t, cli.Status().Update(ctx, model))
+	_, err = r.Reconcile(ctx, prompt)
+	require.NoError(t, err)
+	require.Equal(t, ollamav1alpha1.ReasonWakingModel, prompt.GetCondition(xpv2.TypeReady).Reason)
+}
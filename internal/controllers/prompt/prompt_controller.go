@@ -9,20 +9,22 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/klauspost/compress/gzip"
 	"github.com/klauspost/compress/zstd"
 	ollamaapi "github.com/ollama/ollama/api"
+	ollamamodel "github.com/ollama/ollama/types/model"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/multierr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -33,21 +35,25 @@ import (
 	"sigs.k8s.io/yaml"
 
 	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+	"aerf.io/ollama-operator/internal/authproxy"
 	"aerf.io/ollama-operator/internal/ollamaclient"
 
 	"aerf.io/k8sutils/utilreconcilers"
 )
 
 type Reconciler struct {
-	client               client.Client
+	client client.Client
+	// apiReader reads the api keys Secrets of Models, Secrets are not cached, see cmd/operator
+	apiReader            client.Reader
 	recorder             events.EventRecorder
 	baseHTTPClient       *http.Client
 	ollamaClientProvider ollamaclient.ClientProvider
 }
 
-func newReconciler(cli client.Client, recorder events.EventRecorder, httpCli *http.Client, tp trace.TracerProvider) *Reconciler {
+func newReconciler(cli client.Client, apiReader client.Reader, recorder events.EventRecorder, httpCli *http.Client, tp trace.TracerProvider) *Reconciler {
 	return &Reconciler{
 		client:               cli,
+		apiReader:            apiReader,
 		recorder:             recorder,
 		baseHTTPClient:       httpCli,
 		ollamaClientProvider: ollamaclient.NewProvider(httpCli, tp.Tracer("prompt-controller.ollama-client")),
@@ -55,7 +61,7 @@ func newReconciler(cli client.Client, recorder events.EventRecorder, httpCli *ht
 }
 
 func SetupWithManager(mgr ctrl.Manager, baseHTTPClient *http.Client, tp trace.TracerProvider) error {
-	r := newReconciler(mgr.GetClient(), mgr.GetEventRecorder("ollama-operator.prompt-controller"), baseHTTPClient, tp)
+	r := newReconciler(mgr.GetClient(), mgr.GetAPIReader(), mgr.GetEventRecorder("ollama-operator.prompt-controller"), baseHTTPClient, tp)
 	reconciler := reconcile.AsReconciler(mgr.GetClient(), r)
 	reconciler = utilreconcilers.RequeueOnConflict(reconciler)
 	reconciler = utilreconcilers.NewWithTracingReconciler(
@@ -63,29 +69,26 @@ func SetupWithManager(mgr ctrl.Manager, baseHTTPClient *http.Client, tp trace.Tr
 		tp.Tracer("prompt-controller", trace.WithInstrumentationAttributes(attribute.Stringer("controller-gvk", ollamav1alpha1.PromptGroupVersionKind))),
 	)
 
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &ollamav1alpha1.Prompt{}, modelRefIndex, indexModelRef); err != nil {
+		return errors.Wrap(err, "failed to index prompts by model reference")
+	}
+
+	log := mgr.GetLogger().WithValues("controller", "prompt-controller-watch-handler")
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&ollamav1alpha1.Prompt{}).
 		WatchesRawSource(source.Kind(mgr.GetCache(), &ollamav1alpha1.Model{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, model *ollamav1alpha1.Model) []reconcile.Request {
-			log := mgr.GetLogger().WithValues("controller", "prompt-controller-watch-handler")
-
-			promptList := &ollamav1alpha1.PromptList{}
-			if err := mgr.GetClient().List(ctx, promptList, client.InNamespace(model.GetNamespace())); err != nil {
-				log.Error(err, "unable to list prompts")
-				return nil
+			requests, err := r.promptsForModel(ctx, client.ObjectKeyFromObject(model))
+			if err != nil {
+				log.Error(err, "unable to map model to prompts")
 			}
-
-			var ctrlRequests []ctrl.Request
-			for _, prompt := range promptList.Items {
-				if prompt.Spec.ModelRef.Name == model.GetName() {
-					ctrlRequests = append(ctrlRequests, ctrl.Request{
-						NamespacedName: types.NamespacedName{
-							Namespace: prompt.GetNamespace(),
-							Name:      prompt.GetName(),
-						},
-					})
-				}
+			return requests
+		}))).
+		WatchesRawSource(source.Kind(mgr.GetCache(), &ollamav1alpha1.ModelAccessGrant{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, grant *ollamav1alpha1.ModelAccessGrant) []reconcile.Request {
+			requests, err := r.promptsForGrant(ctx, grant)
+			if err != nil {
+				log.Error(err, "unable to map model access grant to prompts")
 			}
-			return ctrlRequests
+			return requests
 		}))).
 		Complete(reconciler)
 }
@@ -114,11 +117,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, prompt *ollamav1alpha1.Promp
 		return reconcile.Result{}, nil
 	}
 
+	modelKey := modelRefKey(prompt)
+	// checked before fetching the Model, so Prompts can't probe Models in namespaces they aren't granted access to
+	allowed, err := r.modelAccessAllowed(ctx, prompt, modelKey)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !allowed {
+		prompt.SetConditionsWithObservedGeneration(xpv2.Unavailable().WithMessage(fmt.Sprintf("No ModelAccessGrant in namespace %q allows Prompts from namespace %q to use Model %q", modelKey.Namespace, prompt.GetNamespace(), modelKey.Name)))
+		return reconcile.Result{}, nil
+	}
+
 	referencedModel := &ollamav1alpha1.Model{}
-	if err := r.client.Get(ctx, client.ObjectKey{
-		Namespace: cmp.Or(prompt.Spec.ModelRef.Namespace, prompt.GetNamespace()),
-		Name:      prompt.Spec.ModelRef.Name,
-	}, referencedModel); err != nil {
+	if err := r.client.Get(ctx, modelKey, referencedModel); err != nil {
 		if apierrors.IsNotFound(err) {
 			prompt.SetConditionsWithObservedGeneration(xpv2.Unavailable().WithMessage("Referenced model does not exist"))
 			log.V(1).Info("referenced model does not exist")
@@ -127,18 +138,54 @@ func (r *Reconciler) Reconcile(ctx context.Context, prompt *ollamav1alpha1.Promp
 		return reconcile.Result{}, fmt.Errorf("failed to fetch model: %w", err)
 	}
 
-	if !referencedModel.Status.Equal(ollamav1alpha1.NewConditionedStatus(xpv2.Available(), xpv2.ReconcileSuccess())) {
+	modelReady := referencedModel.GetCondition(xpv2.TypeReady).Equal(xpv2.Available())
+	if referencedModel.Status.ScaledToZero || (!modelReady && prompt.GetCondition(xpv2.TypeReady).Reason == ollamav1alpha1.ReasonWakingModel) {
+		if referencedModel.Status.ScaledToZero {
+			// the Model controller scales it up once it notices the use
+			if err := r.markModelUsed(ctx, referencedModel); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		prompt.SetConditionsWithObservedGeneration(xpv2.Condition{
+			Type:               xpv2.TypeReady,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             ollamav1alpha1.ReasonWakingModel,
+			Message:            "Waiting for the Model to scale up from zero",
+		})
+		return reconcile.Result{}, nil
+	}
+
+	if !modelReady || !referencedModel.GetCondition(xpv2.TypeSynced).Equal(xpv2.ReconcileSuccess()) {
 		prompt.SetConditionsWithObservedGeneration(xpv2.Unavailable().WithMessage("Model is not ready and synced"))
 		return reconcile.Result{}, nil
 	}
 
+	desiredModels := referencedModel.DesiredModels()
+	modelName := cmp.Or(prompt.Spec.ModelRef.Model, desiredModels[0].Name)
+	if !slices.ContainsFunc(desiredModels, func(e ollamav1alpha1.ModelEntry) bool { return e.Name == modelName }) {
+		prompt.SetConditionsWithObservedGeneration(xpv2.Unavailable().WithMessage(fmt.Sprintf("Referenced model does not serve %q", modelName)))
+		return reconcile.Result{}, nil
+	}
+
+	if entryStatus := referencedModel.ModelStatusFor(modelName); entryStatus != nil {
+		if err := validatePrompt(prompt, modelName, entryStatus.Details); err != nil {
+			prompt.SetConditionsWithObservedGeneration(xpv2.Unavailable().WithMessage(err.Error()))
+			return reconcile.Result{}, nil
+		}
+	}
+
 	waitingForResponseCond := xpv2.Creating().WithMessage("Waiting for model response")
 	if !prompt.Status.GetCondition(xpv2.TypeReady).Equal(waitingForResponseCond) {
 		prompt.SetConditionsWithObservedGeneration(waitingForResponseCond)
 		return reconcile.Result{Requeue: true}, nil
 	}
 
-	ollamaCli := r.ollamaClientProvider.ForModel(referencedModel)
+	adminKey, err := r.adminKeyOf(ctx, referencedModel)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	ollamaCli := r.ollamaClientProvider.ForModel(referencedModel, adminKey)
 
 	opts, err := r.getOptionsFromSpecOptions(prompt)
 	if err != nil {
@@ -176,7 +223,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, prompt *ollamav1alpha1.Promp
 
 	generateResp := ollamaapi.GenerateResponse{}
 	req := &ollamaapi.GenerateRequest{
-		Model:    referencedModel.Spec.Model,
+		Model:    modelName,
 		Prompt:   prompt.Spec.Prompt,
 		Suffix:   prompt.Spec.Suffix,
 		System:   prompt.Spec.System,
@@ -220,9 +267,36 @@ func (r *Reconciler) Reconcile(ctx context.Context, prompt *ollamav1alpha1.Promp
 
 	prompt.SetConditionsWithObservedGeneration(xpv2.Available())
 
+	if err := r.markModelUsed(ctx, referencedModel); err != nil {
+		// the response is there already, the Model may just be scaled to zero a bit earlier
+		log.Error(err, "failed to mark the Model as used")
+	}
 	return reconcile.Result{}, nil
 }
 
+// markModelUsed records the use of a Model with spec.idle, which wakes it up if it's scaled to zero.
+func (r *Reconciler) markModelUsed(ctx context.Context, model *ollamav1alpha1.Model) error {
+	if model.Spec.Idle == nil {
+		return nil
+	}
+	patch := client.MergeFrom(model.DeepCopy())
+	metav1.SetMetaDataAnnotation(&model.ObjectMeta, ollamav1alpha1.ModelLastUsedAnnotation, time.Now().UTC().Format(time.RFC3339))
+	return errors.Wrap(r.client.Patch(ctx, model, patch), "failed to mark the Model as used")
+}
+
+// validatePrompt checks that the model supports everything the Prompt uses, based on capabilities reported by Ollama.
+func validatePrompt(prompt *ollamav1alpha1.Prompt, modelName string, details *ollamav1alpha1.OllamaModelDetails) error {
+	switch {
+	case !details.HasCapability(ollamamodel.CapabilityCompletion.String()):
+		return fmt.Errorf("model %q does not support completion, its capabilities are: %s", modelName, strings.Join(details.Capabilities, ", "))
+	case len(prompt.Spec.Images) > 0 && !details.HasCapability(ollamamodel.CapabilityVision.String()):
+		return fmt.Errorf("model %q does not support images", modelName)
+	case prompt.Spec.Suffix != "" && !details.HasCapability(ollamamodel.CapabilityInsert.String()):
+		return fmt.Errorf("model %q does not support suffix", modelName)
+	}
+	return nil
+}
+
 func (r *Reconciler) getOptionsFromSpecOptions(prompt *ollamav1alpha1.Prompt) (map[string]any, error) {
 	raw := prompt.Spec.Options.Raw
 	if len(raw) == 0 {
@@ -302,3 +376,15 @@ func extractRawImageData(ctx context.Context, cli client.Reader, ref ollamav1alp
 	imgData := ollamav1alpha1.ImageData{}
 	return imgData, errors.Wrap(yaml.Unmarshal(data, &imgData), "failed to unmarshal image data")
 }
+
+// adminKeyOf returns the operator's key of the Model from its api keys Secret, or an empty one if it has no spec.auth.
+func (r *Reconciler) adminKeyOf(ctx context.Context, model *ollamav1alpha1.Model) (string, error) {
+	if model.Status.Auth == nil {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.apiReader.Get(ctx, client.ObjectKey{Namespace: model.GetNamespace(), Name: model.Status.Auth.SecretName}, secret); err != nil {
+		return "", errors.Wrap(err, "failed to fetch api keys secret of the Model")
+	}
+	return string(secret.Data[authproxy.OperatorKeyName]), nil
+}
@@ -0,0 +1,54 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
+)
+
+func Test_validatePrompt(t *testing.T) {
+	withImages := &ollamav1alpha1.Prompt{Spec: ollamav1alpha1.PromptSpec{
+		Prompt: "What's in the picture?",
+		Images: []ollamav1alpha1.ImageSource{{Inline: &ollamav1alpha1.ImageData{Data: "aW1hZ2U="}}},
+	}}
+	tests := []struct {
+		name        string
+		prompt      *ollamav1alpha1.Prompt
+		details     *ollamav1alpha1.OllamaModelDetails
+		errContains string
+	}{
+		{
+			name:    "images with vision model",
+			prompt:  withImages,
+			details: &ollamav1alpha1.OllamaModelDetails{Capabilities: []string{"completion", "vision"}},
+		},
+		{
+			name:        "images without vision",
+			prompt:      withImages,
+			details:     &ollamav1alpha1.OllamaModelDetails{Capabilities: []string{"completion", "tools"}},
+			errContains: "does not support images",
+		},
+		{
+			name:        "embedding model",
+			prompt:      &ollamav1alpha1.Prompt{Spec: ollamav1alpha1.PromptSpec{Prompt: "Hi"}},
+			details:     &ollamav1alpha1.OllamaModelDetails{Capabilities: []string{"embedding"}},
+			errContains: "does not support completion",
+		},
+		{
+			name:   "capabilities not reported yet",
+			prompt: withImages,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePrompt(tt.prompt, "test", tt.details)
+			if tt.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
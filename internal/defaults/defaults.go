@@ -4,5 +4,16 @@ const (
 	// renovate: datasource=docker depName=docker.io/ollama/ollama
 	OllamaImage = "docker.io/ollama/ollama:0.32.9"
 
+	// renovate: datasource=docker depName=docker.io/library/busybox
+	BlobServerImage = "docker.io/library/busybox:1.37.0"
+
+	// AuthProxyImage is built from ./cmd/auth-proxy, the operator overrides it with the image matching its version.
+	AuthProxyImage = "ghcr.io/aerfio/ollama-operator/auth-proxy:latest"
+
 	OllamaPort = 11434
+
+	BlobServerPort = 11435
+
+	// AuthProxyUpstreamPort is the localhost port Ollama listens on when the auth proxy takes over OllamaPort.
+	AuthProxyUpstreamPort = 11433
 )
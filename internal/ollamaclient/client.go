@@ -2,6 +2,9 @@ package ollamaclient
 
 import (
 	"context"
+	"errors"
+	"io"
+	"net/http"
 
 	ollamaapi "github.com/ollama/ollama/api"
 	"go.opentelemetry.io/otel/codes"
@@ -15,6 +18,16 @@ type Interface interface {
 	List(ctx context.Context) (*ollamaapi.ListResponse, error)
 	Pull(ctx context.Context, req *ollamaapi.PullRequest, progressFunc ollamaapi.PullProgressFunc) error
 	Generate(ctx context.Context, req *ollamaapi.GenerateRequest, progressFunc ollamaapi.GenerateResponseFunc) error
+	Delete(ctx context.Context, req *ollamaapi.DeleteRequest) error
+	Create(ctx context.Context, req *ollamaapi.CreateRequest, progressFunc ollamaapi.CreateProgressFunc) error
+	CreateBlob(ctx context.Context, digest string, r io.Reader) error
+	ListRunning(ctx context.Context) (*ollamaapi.ProcessResponse, error)
+}
+
+// IsNotFound returns true if the error was returned by Ollama API for a model that does not exist.
+func IsNotFound(err error) bool {
+	var statusErr ollamaapi.StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
 }
 
 func NewTracingAwareClient(wrapped Interface, tracer trace.Tracer) Interface {
@@ -80,3 +93,55 @@ func (t *tracingAwareClient) Show(ctx context.Context, req *ollamaapi.ShowReques
 	span.SetStatus(codes.Ok, "success")
 	return resp, nil
 }
+
+func (t *tracingAwareClient) Delete(ctx context.Context, req *ollamaapi.DeleteRequest) error {
+	ctx, span := t.tracer.Start(ctx, "delete")
+	defer span.End()
+
+	err := t.wrapped.Delete(ctx, req)
+	if err != nil {
+		k8stracing.SetSpanErr(span, err)
+		return err
+	}
+	span.SetStatus(codes.Ok, "success")
+	return nil
+}
+
+func (t *tracingAwareClient) Create(ctx context.Context, req *ollamaapi.CreateRequest, progressFunc ollamaapi.CreateProgressFunc) error {
+	ctx, span := t.tracer.Start(ctx, "create")
+	defer span.End()
+
+	err := t.wrapped.Create(ctx, req, progressFunc)
+	if err != nil {
+		k8stracing.SetSpanErr(span, err)
+		return err
+	}
+	span.SetStatus(codes.Ok, "success")
+	return nil
+}
+
+func (t *tracingAwareClient) CreateBlob(ctx context.Context, digest string, r io.Reader) error {
+	ctx, span := t.tracer.Start(ctx, "create-blob")
+	defer span.End()
+
+	err := t.wrapped.CreateBlob(ctx, digest, r)
+	if err != nil {
+		k8stracing.SetSpanErr(span, err)
+		return err
+	}
+	span.SetStatus(codes.Ok, "success")
+	return nil
+}
+
+func (t *tracingAwareClient) ListRunning(ctx context.Context) (*ollamaapi.ProcessResponse, error) {
+	ctx, span := t.tracer.Start(ctx, "list-running")
+	defer span.End()
+
+	resp, err := t.wrapped.ListRunning(ctx)
+	if err != nil {
+		k8stracing.SetSpanErr(span, err)
+		return resp, err
+	}
+	span.SetStatus(codes.Ok, "success")
+	return resp, nil
+}
@@ -2,8 +2,10 @@ package ollamaclient
 
 import (
 	"context"
+	"io"
 
 	"github.com/ollama/ollama/api"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -12,10 +14,14 @@ type (
 		Client *TestOllamaClient
 	}
 	TestOllamaClient struct {
-		OnGenerate func(ctx context.Context, req *api.GenerateRequest, progressFunc api.GenerateResponseFunc) error
-		OnList     func(ctx context.Context) (*api.ListResponse, error)
-		OnPull     func(ctx context.Context, req *api.PullRequest, progressFunc api.PullProgressFunc) error
-		OnShow     func(ctx context.Context, req *api.ShowRequest) (*api.ShowResponse, error)
+		OnGenerate    func(ctx context.Context, req *api.GenerateRequest, progressFunc api.GenerateResponseFunc) error
+		OnList        func(ctx context.Context) (*api.ListResponse, error)
+		OnPull        func(ctx context.Context, req *api.PullRequest, progressFunc api.PullProgressFunc) error
+		OnShow        func(ctx context.Context, req *api.ShowRequest) (*api.ShowResponse, error)
+		OnDelete      func(ctx context.Context, req *api.DeleteRequest) error
+		OnCreate      func(ctx context.Context, req *api.CreateRequest, progressFunc api.CreateProgressFunc) error
+		OnCreateBlob  func(ctx context.Context, digest string, r io.Reader) error
+		OnListRunning func(ctx context.Context) (*api.ProcessResponse, error)
 	}
 )
 
@@ -24,7 +30,11 @@ var (
 	_ Interface      = &TestOllamaClient{}
 )
 
-func (t *TestOllamaClientProvider) ForModel(model metav1.Object) Interface {
+func (t *TestOllamaClientProvider) ForModel(model metav1.Object, apiKey string) Interface {
+	return t.Client
+}
+
+func (t *TestOllamaClientProvider) ForPod(pod *corev1.Pod, apiKey string) Interface {
 	return t.Client
 }
 
@@ -43,3 +53,19 @@ func (t *TestOllamaClient) Pull(ctx context.Context, req *api.PullRequest, progr
 func (t *TestOllamaClient) Show(ctx context.Context, req *api.ShowRequest) (*api.ShowResponse, error) {
 	return t.OnShow(ctx, req)
 }
+
+func (t *TestOllamaClient) Delete(ctx context.Context, req *api.DeleteRequest) error {
+	return t.OnDelete(ctx, req)
+}
+
+func (t *TestOllamaClient) Create(ctx context.Context, req *api.CreateRequest, progressFunc api.CreateProgressFunc) error {
+	return t.OnCreate(ctx, req, progressFunc)
+}
+
+func (t *TestOllamaClient) CreateBlob(ctx context.Context, digest string, r io.Reader) error {
+	return t.OnCreateBlob(ctx, digest, r)
+}
+
+func (t *TestOllamaClient) ListRunning(ctx context.Context) (*api.ProcessResponse, error) {
+	return t.OnListRunning(ctx)
+}
@@ -1,6 +1,7 @@
 package ollamaclient
 
 import (
+	"cmp"
 	"fmt"
 	"net"
 	"net/http"
@@ -9,13 +10,19 @@ import (
 
 	ollamaapi "github.com/ollama/ollama/api"
 	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	ollamav1alpha1 "aerf.io/ollama-operator/apis/ollama/v1alpha1"
 	"aerf.io/ollama-operator/internal/defaults"
 )
 
 type ClientProvider interface {
-	ForModel(model metav1.Object) Interface
+	// ForModel returns client talking to the Service of the Model, authenticated with apiKey if it's not empty.
+	ForModel(model metav1.Object, apiKey string) Interface
+	// ForPod returns client talking directly to given Ollama pod, bypassing the Service, authenticated with apiKey
+	// if it's not empty.
+	ForPod(pod *corev1.Pod, apiKey string) Interface
 }
 
 func NewProvider(baseHTTPClient *http.Client, tracer trace.Tracer) ClientProvider {
@@ -25,17 +32,53 @@ func NewProvider(baseHTTPClient *http.Client, tracer trace.Tracer) ClientProvide
 	}
 }
 
+type bearerTransport struct {
+	key  string
+	base http.RoundTripper
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.key)
+	return t.base.RoundTrip(req)
+}
+
 type Provider struct {
 	baseHTTPClient *http.Client
 	tracer         trace.Tracer
 }
 
-func (p *Provider) ForModel(model metav1.Object) Interface {
+// ForModel returns client talking to the Service of the Model, or of the OllamaServer hosting it.
+func (p *Provider) ForModel(model metav1.Object, apiKey string) Interface {
+	serviceName := model.GetName()
+	if m, ok := model.(*ollamav1alpha1.Model); ok && m.Spec.ServerRef != nil {
+		serviceName = m.Spec.ServerRef.Name
+	}
 	u := &url.URL{
 		Scheme: "http",
 		// use orbstack k8s locally to run that llm container
-		Host: net.JoinHostPort(fmt.Sprintf("%s.%s.svc.cluster.local", model.GetName(), model.GetNamespace()), strconv.Itoa(defaults.OllamaPort)),
+		Host: net.JoinHostPort(fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, model.GetNamespace()), strconv.Itoa(defaults.OllamaPort)),
+	}
+
+	return NewTracingAwareClient(ollamaapi.NewClient(u, p.httpClient(apiKey)), p.tracer)
+}
+
+func (p *Provider) ForPod(pod *corev1.Pod, apiKey string) Interface {
+	u := &url.URL{
+		Scheme: "http",
+		Host:   net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(defaults.OllamaPort)),
 	}
 
-	return NewTracingAwareClient(ollamaapi.NewClient(u, p.baseHTTPClient), p.tracer)
+	return NewTracingAwareClient(ollamaapi.NewClient(u, p.httpClient(apiKey)), p.tracer)
+}
+
+// httpClient returns the base HTTP client, sending apiKey to the auth proxy in front of Ollama if it's not empty.
+// The key is sent only to Models with spec.auth, each of them has its own.
+func (p *Provider) httpClient(apiKey string) *http.Client {
+	if apiKey == "" {
+		return p.baseHTTPClient
+	}
+	withKey := *p.baseHTTPClient
+	withKey.Transport = &bearerTransport{key: apiKey, base: cmp.Or[http.RoundTripper](p.baseHTTPClient.Transport, http.DefaultTransport)}
+	return &withKey
 }